@@ -0,0 +1,183 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BigVersion is a counterpart of [SemVer] whose major, minor and patch versions
+// are decimal strings of arbitrary length, since [spec item 2] places no bound on them
+// (e.g. "1.0.99999999999999999999" or date-based patch numbers).
+// An empty version number is treated as "0", so BigVersion's [zero value] is "0.0.0" version.
+//
+// [spec item 2]: https://semver.org/#spec-item-2
+// [zero value]: https://go.dev/ref/spec#The_zero_value
+type BigVersion struct {
+	// Major contains the decimal [major version].
+	//
+	// [major version]: https://semver.org/#spec-item-8
+	Major string
+	// Minor contains the decimal [minor version].
+	//
+	// [minor version]: https://semver.org/#spec-item-7
+	Minor string
+	// Patch contains the decimal [patch version].
+	//
+	// [patch version]: https://semver.org/#spec-item-6
+	Patch string
+	// PreRelease contains the [pre-release version].
+	//
+	// [pre-release version]: https://semver.org/#spec-item-9
+	PreRelease string
+	// Build contains the [build metadata].
+	//
+	// [build metadata]: https://semver.org/#spec-item-10
+	Build string
+}
+
+// bigNumber returns the version number 'n' with the empty string replaced by "0".
+func bigNumber(n string) string {
+	if len(n) == 0 {
+		return "0"
+	}
+	return n
+}
+
+// ParseBig converts the version string to a [BigVersion].
+// Unlike [Parse], ParseBig accepts version numbers of any magnitude.
+func ParseBig(s string) (BigVersion, error) {
+	p, err := splitVersion(s)
+	if err != nil {
+		return BigVersion{}, err
+	}
+	for _, n := range []string{p.major, p.minor, p.patch} {
+		if err := validVersionNumber(n); err != nil {
+			return BigVersion{}, err
+		}
+	}
+	return BigVersion{Major: p.major, Minor: p.minor, Patch: p.patch, PreRelease: p.preRelease, Build: p.build}, nil
+}
+
+// ValidBig checks 'bv' for [validity]. If 'bv' is not valid corresponding error is returned.
+//
+// [validity]: https://semver.org/#semantic-versioning-specification-semver
+func ValidBig(bv BigVersion) error {
+	// https://semver.org/#spec-item-2
+	for _, n := range []string{bv.Major, bv.Minor, bv.Patch} {
+		if err := validVersionNumber(bigNumber(n)); err != nil {
+			return err
+		}
+	}
+	// https://semver.org/#spec-item-9
+	if err := validExt(bv.PreRelease, true); err != nil {
+		return err
+	}
+	// https://semver.org/#spec-item-10
+	if err := validExt(bv.Build, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CompareBig [compares] 'bv1' with 'bv2'.
+// CompareBig returns -1 if 'bv1' is less than 'bv2', 0 if 'bv1' is equal to 'bv2', 1 if 'bv1' is more than 'bv2'.
+//
+// [compares]: https://semver.org/#spec-item-11
+func CompareBig(bv1, bv2 BigVersion) (int, error) {
+	if err := ValidBig(bv1); err != nil {
+		return 0, err
+	}
+	if err := ValidBig(bv2); err != nil {
+		return 0, err
+	}
+	// https://semver.org/#spec-item-11
+	if r := compareNumeric(bigNumber(bv1.Major), bigNumber(bv2.Major)); r != 0 {
+		return r, nil
+	}
+	if r := compareNumeric(bigNumber(bv1.Minor), bigNumber(bv2.Minor)); r != 0 {
+		return r, nil
+	}
+	if r := compareNumeric(bigNumber(bv1.Patch), bigNumber(bv2.Patch)); r != 0 {
+		return r, nil
+	}
+	return comparePreRelease(bv1.PreRelease, bv2.PreRelease), nil
+}
+
+// String implements the [fmt.Stringer] interface.
+// Like [SemVer.String], String does not validate 'bv'.
+func (bv BigVersion) String() string {
+	s := bigNumber(bv.Major) + "." + bigNumber(bv.Minor) + "." + bigNumber(bv.Patch)
+	if len(bv.PreRelease) > 0 {
+		s += "-" + bv.PreRelease
+	}
+	if len(bv.Build) > 0 {
+		s += "+" + bv.Build
+	}
+	return s
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// Like [BigVersion.String], MarshalText does not validate 'bv'.
+func (bv BigVersion) MarshalText() ([]byte, error) {
+	return []byte(bv.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (bv *BigVersion) UnmarshalText(text []byte) error {
+	v, err := ParseBig(string(text))
+	if err != nil {
+		return err
+	}
+	*bv = v
+	return nil
+}
+
+// IsValid reports whether 'bv' is [valid].
+//
+// [valid]: https://semver.org/#semantic-versioning-specification-semver
+func (bv BigVersion) IsValid() bool {
+	return ValidBig(bv) == nil
+}
+
+// SemVer converts 'bv' to a [SemVer].
+// SemVer returns an error if 'bv' is invalid or any of its version numbers overflows int64.
+func (bv BigVersion) SemVer() (SemVer, error) {
+	if err := ValidBig(bv); err != nil {
+		return SemVer{}, err
+	}
+	sv := SemVer{PreRelease: bv.PreRelease, Build: bv.Build}
+	var err error
+	if sv.Major, err = bigToInt64(bv.Major); err != nil {
+		return SemVer{}, err
+	}
+	if sv.Minor, err = bigToInt64(bv.Minor); err != nil {
+		return SemVer{}, err
+	}
+	if sv.Patch, err = bigToInt64(bv.Patch); err != nil {
+		return SemVer{}, err
+	}
+	return sv, nil
+}
+
+func bigToInt64(n string) (int64, error) {
+	i, err := strconv.ParseInt(bigNumber(n), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("version number overflows int64 (%w)", err)
+	}
+	return i, nil
+}
+
+// BigVersion converts 'v' to a [BigVersion].
+// BigVersion returns an error if 'v' is invalid.
+func (v SemVer) BigVersion() (BigVersion, error) {
+	if err := Valid(v); err != nil {
+		return BigVersion{}, err
+	}
+	return BigVersion{
+		Major:      strconv.FormatInt(v.Major, 10),
+		Minor:      strconv.FormatInt(v.Minor, 10),
+		Patch:      strconv.FormatInt(v.Patch, 10),
+		PreRelease: v.PreRelease,
+		Build:      v.Build,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBig(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    BigVersion
+		wantErr bool
+	}{
+		{name: "01", s: "", wantErr: true},
+		{name: "02", s: "1.0.099999999999999999999", wantErr: true},
+		{name: "03", s: "1.0.-1", wantErr: true},
+		{name: "04", s: "1.0.0-01", wantErr: true},
+		{name: "1", s: "0.0.0", want: BigVersion{Major: "0", Minor: "0", Patch: "0"}},
+		{name: "2",
+			s:    "1.0.99999999999999999999",
+			want: BigVersion{Major: "1", Minor: "0", Patch: "99999999999999999999"},
+		},
+		{name: "3",
+			s:    "123456789012345678901234567890.2.3-rc.1+b",
+			want: BigVersion{Major: "123456789012345678901234567890", Minor: "2", Patch: "3", PreRelease: "rc.1", Build: "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBig(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBig() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.s {
+				t.Errorf("BigVersion.String() = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestValidBig(t *testing.T) {
+	tests := []struct {
+		name    string
+		bv      BigVersion
+		wantErr bool
+	}{
+		{name: "01", bv: BigVersion{Major: "-1"}, wantErr: true},
+		{name: "02", bv: BigVersion{Minor: "01"}, wantErr: true},
+		{name: "03", bv: BigVersion{Patch: "1a"}, wantErr: true},
+		{name: "04", bv: BigVersion{PreRelease: "01"}, wantErr: true},
+		{name: "1", bv: BigVersion{}},
+		{name: "2", bv: BigVersion{Major: "99999999999999999999", Build: "01"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidBig(tt.bv); (err != nil) != tt.wantErr {
+				t.Errorf("ValidBig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompareBig(t *testing.T) {
+	tests := []struct {
+		name    string
+		bv1     BigVersion
+		bv2     BigVersion
+		want    int
+		wantErr bool
+	}{
+		{name: "01", bv1: BigVersion{Major: "x"}, wantErr: true},
+		{name: "02", bv2: BigVersion{Major: "x"}, wantErr: true},
+		{name: "1", want: 0},
+		{name: "2", bv1: BigVersion{Major: "0"}, bv2: BigVersion{}, want: 0},
+		{name: "3", bv1: BigVersion{Patch: "99999999999999999999"}, bv2: BigVersion{Patch: "100000000000000000000"}, want: -1},
+		{name: "4", bv1: BigVersion{Minor: "10"}, bv2: BigVersion{Minor: "9", Patch: "99999999999999999999"}, want: 1},
+		{name: "5", bv1: BigVersion{Major: "1", PreRelease: "rc.1"}, bv2: BigVersion{Major: "1"}, want: -1},
+		{name: "6", bv1: BigVersion{Major: "1", Build: "a"}, bv2: BigVersion{Major: "1", Build: "b"}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompareBig(tt.bv1, tt.bv2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CompareBig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CompareBig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBigVersion_SemVer(t *testing.T) {
+	tests := []struct {
+		name    string
+		bv      BigVersion
+		want    SemVer
+		wantErr bool
+	}{
+		{name: "01", bv: BigVersion{Patch: "99999999999999999999"}, wantErr: true},
+		{name: "02", bv: BigVersion{Major: "9223372036854775808"}, wantErr: true},
+		{name: "03", bv: BigVersion{Major: "01"}, wantErr: true},
+		{name: "1", bv: BigVersion{}, want: SemVer{}},
+		{name: "2",
+			bv:   BigVersion{Major: "9223372036854775807", Minor: "2", Patch: "3", PreRelease: "rc", Build: "b"},
+			want: SemVer{Major: 9223372036854775807, Minor: 2, Patch: 3, PreRelease: "rc", Build: "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.bv.SemVer()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BigVersion.SemVer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BigVersion.SemVer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVer_BigVersion(t *testing.T) {
+	if _, err := (SemVer{Major: -1}).BigVersion(); err == nil {
+		t.Errorf("SemVer.BigVersion() error = nil, want error")
+	}
+	got, err := parseMust("1.2.3-rc.1+b").BigVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BigVersion{Major: "1", Minor: "2", Patch: "3", PreRelease: "rc.1", Build: "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SemVer.BigVersion() = %v, want %v", got, want)
+	}
+}
+
+func TestBigVersion_UnmarshalText(t *testing.T) {
+	var bv BigVersion
+	if err := bv.UnmarshalText([]byte("1.2")); err == nil {
+		t.Errorf("BigVersion.UnmarshalText() error = nil, want error")
+	}
+	if err := bv.UnmarshalText([]byte("1.2.99999999999999999999")); err != nil {
+		t.Fatal(err)
+	}
+	text, _ := bv.MarshalText()
+	if string(text) != "1.2.99999999999999999999" {
+		t.Errorf("BigVersion.MarshalText() = %s, want %s", text, "1.2.99999999999999999999")
+	}
+}
@@ -0,0 +1,193 @@
+package semver
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BuildMetadata is a structured view of the identifiers of [build metadata].
+// BuildMetadata's zero value is empty build metadata.
+//
+// [build metadata]: https://semver.org/#spec-item-10
+type BuildMetadata struct {
+	ids []Identifier
+}
+
+// ParseBuildMetadata converts the build metadata string
+// (without the leading '+') to a [BuildMetadata].
+func ParseBuildMetadata(s string) (BuildMetadata, error) {
+	if err := validExt(s, false); err != nil {
+		return BuildMetadata{}, err
+	}
+	return BuildMetadata{ids: splitIdentifiers(s)}, nil
+}
+
+// BuildMetadata returns the structured view of the build metadata of 'v'.
+// Like [SemVer.String], BuildMetadata does not validate 'v'.
+func (v SemVer) BuildMetadata() BuildMetadata {
+	return BuildMetadata{ids: splitIdentifiers(v.Build)}
+}
+
+// Len returns the number of identifiers in 'b'.
+func (b BuildMetadata) Len() int {
+	return len(b.ids)
+}
+
+// Identifier returns the i-th identifier of 'b'.
+// Identifier panics if 'i' is out of range.
+func (b BuildMetadata) Identifier(i int) Identifier {
+	return b.ids[i]
+}
+
+// Identifiers returns a copy of the identifiers of 'b'.
+func (b BuildMetadata) Identifiers() []Identifier {
+	return append([]Identifier(nil), b.ids...)
+}
+
+// String implements the [fmt.Stringer] interface.
+// String returns the identifiers of 'b' joined with '.', suitable for [SemVer.Build].
+func (b BuildMetadata) String() string {
+	return joinIdentifiers(b.ids)
+}
+
+// Append returns 'b' with 'ids' appended.
+// Append returns an error if any of 'ids' is not a valid build identifier.
+func (b BuildMetadata) Append(ids ...string) (BuildMetadata, error) {
+	r, err := appendIdentifiers(b.ids, false, ids)
+	if err != nil {
+		return BuildMetadata{}, err
+	}
+	return BuildMetadata{ids: r}, nil
+}
+
+// PairConvention describes how key/value pairs are encoded in [BuildMetadata].
+// PairConvention's zero value encodes pairs as consecutive identifiers
+// (e.g. "git.3a9f2c1.ci.1234").
+type PairConvention struct {
+	// Separator, if not empty, separates key and value within a single identifier
+	// (e.g. "-" for "git-3a9f2c1.ci-1234").
+	// If Separator is empty, key and value are consecutive identifiers.
+	Separator string
+	// Keys, if not empty, lists the recognised keys; identifiers not belonging
+	// to a pair with a recognised key are skipped.
+	// If Keys is empty, consecutive identifiers are paired from the start
+	// (or every identifier containing Separator is a pair).
+	Keys []string
+}
+
+// Pair is a key/value pair of [BuildMetadata].
+type Pair struct {
+	Key   string
+	Value string
+}
+
+func (c PairConvention) recognised(key string) bool {
+	return len(c.Keys) == 0 || slices.Contains(c.Keys, key)
+}
+
+// Pairs returns the key/value pairs of 'b' encoded according to 'c', in order of appearance.
+func (b BuildMetadata) Pairs(c PairConvention) []Pair {
+	var pp []Pair
+	if len(c.Separator) > 0 {
+		for _, id := range b.ids {
+			key, value, ok := strings.Cut(string(id), c.Separator)
+			if ok && c.recognised(key) {
+				pp = append(pp, Pair{Key: key, Value: value})
+			}
+		}
+		return pp
+	}
+	for i := 0; i+1 < len(b.ids); {
+		key := string(b.ids[i])
+		if !c.recognised(key) {
+			i++
+			continue
+		}
+		pp = append(pp, Pair{Key: key, Value: string(b.ids[i+1])})
+		i += 2
+	}
+	return pp
+}
+
+// Lookup returns the value of the first pair of 'b' with 'key' encoded according to 'c'.
+// The boolean result reports whether such pair exists.
+func (b BuildMetadata) Lookup(key string, c PairConvention) (string, bool) {
+	for _, p := range b.Pairs(c) {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// AppendPair returns 'b' with the 'key'/'value' pair appended according to 'c'.
+// AppendPair returns an error if the resulting identifiers are not valid build identifiers.
+func (b BuildMetadata) AppendPair(key, value string, c PairConvention) (BuildMetadata, error) {
+	if len(c.Separator) > 0 {
+		return b.Append(key + c.Separator + value)
+	}
+	return b.Append(key, value)
+}
+
+// Commit returns the commit hash stored in 'b' under 'key' according to 'c'.
+// A commit hash consists of 7 to 64 hexadecimal digits.
+func (b BuildMetadata) Commit(key string, c PairConvention) (string, error) {
+	value, ok := b.Lookup(key, c)
+	if !ok {
+		return "", errors.New("build metadata key not found")
+	}
+	if !isCommitHash(value) {
+		return "", errors.New("malformed commit hash")
+	}
+	return value, nil
+}
+
+func isCommitHash(s string) bool {
+	if len(s) < 7 || len(s) > 64 {
+		return false
+	}
+	for _, r := range s {
+		if !(('0' <= r && r <= '9') || ('a' <= r && r <= 'f') || ('A' <= r && r <= 'F')) {
+			return false
+		}
+	}
+	return true
+}
+
+// TimeLayout is the compact RFC 3339-like layout of timestamps in [BuildMetadata].
+// Colons and other punctuation are omitted, since [build identifiers] may contain only [0-9A-Za-z-].
+//
+// [build identifiers]: https://semver.org/#spec-item-10
+const TimeLayout = "20060102T150405Z"
+
+// timeLayouts are the layouts accepted by [BuildMetadata.Time], all in UTC.
+var timeLayouts = []string{
+	TimeLayout,
+	"20060102T150405",
+	"20060102150405",
+	"20060102",
+}
+
+// Time returns the timestamp stored in 'b' under 'key' according to 'c'.
+// The timestamp must be in [TimeLayout] or in one of its shortened forms
+// ("20060102T150405", "20060102150405", "20060102"), and is interpreted as UTC.
+func (b BuildMetadata) Time(key string, c PairConvention) (time.Time, error) {
+	value, ok := b.Lookup(key, c)
+	if !ok {
+		return time.Time{}, errors.New("build metadata key not found")
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("malformed timestamp")
+}
+
+// AppendTime returns 'b' with the 'key'/'t' pair appended according to 'c'.
+// 't' is converted to UTC and formatted with [TimeLayout].
+func (b BuildMetadata) AppendTime(key string, t time.Time, c PairConvention) (BuildMetadata, error) {
+	return b.AppendPair(key, t.UTC().Format(TimeLayout), c)
+}
@@ -0,0 +1,157 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseBuildMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []Identifier
+		wantErr bool
+	}{
+		{name: "01", s: "a..b", wantErr: true},
+		{name: "02", s: "a+b", wantErr: true},
+		{name: "1", s: ""},
+		{name: "2", s: "git.3a9f2c1.01", want: []Identifier{"git", "3a9f2c1", "01"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBuildMetadata(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBuildMetadata() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got.Identifiers(), tt.want) {
+				t.Errorf("ParseBuildMetadata() = %v, want %v", got.Identifiers(), tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMetadata_Pairs(t *testing.T) {
+	tests := []struct {
+		name string
+		b    BuildMetadata
+		c    PairConvention
+		want []Pair
+	}{
+		{name: "1"},
+		{name: "2",
+			b:    parseMust("1.0.0+git.3a9f2c1.ts.20260105T101500Z.ci.1234").BuildMetadata(),
+			want: []Pair{{"git", "3a9f2c1"}, {"ts", "20260105T101500Z"}, {"ci", "1234"}},
+		},
+		{name: "3",
+			b:    parseMust("1.0.0+git.3a9f2c1.odd").BuildMetadata(),
+			want: []Pair{{"git", "3a9f2c1"}},
+		},
+		{name: "4",
+			b:    parseMust("1.0.0+linux.git.3a9f2c1.amd64.ci.1234").BuildMetadata(),
+			c:    PairConvention{Keys: []string{"git", "ci"}},
+			want: []Pair{{"git", "3a9f2c1"}, {"ci", "1234"}},
+		},
+		{name: "5",
+			b:    parseMust("1.0.0+git-3a9f2c1.linux.ci-1234").BuildMetadata(),
+			c:    PairConvention{Separator: "-"},
+			want: []Pair{{"git", "3a9f2c1"}, {"ci", "1234"}},
+		},
+		{name: "6",
+			b:    parseMust("1.0.0+git-3a9f2c1.linux.ci-1234").BuildMetadata(),
+			c:    PairConvention{Separator: "-", Keys: []string{"ci"}},
+			want: []Pair{{"ci", "1234"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.Pairs(tt.c); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildMetadata.Pairs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMetadata_Commit(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   string
+		want    string
+		wantErr bool
+	}{
+		{name: "01", build: "ci.1234", wantErr: true},
+		{name: "02", build: "git.3a9f2", wantErr: true},
+		{name: "03", build: "git.3a9f2cz", wantErr: true},
+		{name: "1", build: "git.3a9f2c1", want: "3a9f2c1"},
+		{name: "2", build: "git.3A9F2C1D", want: "3A9F2C1D"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SemVer{Build: tt.build}.BuildMetadata().Commit("git", PairConvention{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildMetadata.Commit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("BuildMetadata.Commit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMetadata_Time(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "01", build: "git.3a9f2c1", wantErr: true},
+		{name: "02", build: "ts.2026-01-05", wantErr: true},
+		{name: "03", build: "ts.20261305", wantErr: true},
+		{name: "1", build: "ts.20260105T101500Z", want: time.Date(2026, 1, 5, 10, 15, 0, 0, time.UTC)},
+		{name: "2", build: "ts.20260105T101500", want: time.Date(2026, 1, 5, 10, 15, 0, 0, time.UTC)},
+		{name: "3", build: "ts.20260105101500", want: time.Date(2026, 1, 5, 10, 15, 0, 0, time.UTC)},
+		{name: "4", build: "ts.20260105", want: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SemVer{Build: tt.build}.BuildMetadata().Time("ts", PairConvention{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildMetadata.Time() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("BuildMetadata.Time() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMetadata_Append(t *testing.T) {
+	var b BuildMetadata
+	var err error
+	if b, err = b.AppendPair("git", "3a9f2c1", PairConvention{}); err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2026, 1, 5, 11, 15, 0, 0, time.FixedZone("CET", 3600))
+	if b, err = b.AppendTime("ts", ts, PairConvention{}); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = b.AppendPair("ci", "1234", PairConvention{Separator: "-"}); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = b.Append("01"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "git.3a9f2c1.ts.20260105T101500Z.ci-1234.01"; got != want {
+		t.Errorf("BuildMetadata.String() = %v, want %v", got, want)
+	}
+	if _, err := b.Append("a_b"); err == nil {
+		t.Errorf("BuildMetadata.Append() error = nil, want error")
+	}
+	if _, err := b.AppendPair("k", "v.w", PairConvention{}); err == nil {
+		t.Errorf("BuildMetadata.AppendPair() error = nil, want error")
+	}
+}
@@ -0,0 +1,143 @@
+package buildversion
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solsw/semver"
+)
+
+// Version is the version of the binary set at link time. A leading "v" is allowed.
+// If Version is empty, [Current] uses the build information embedded in the binary.
+var Version string
+
+// Build metadata added by [FromBuildInfo], as consecutive identifiers
+// (see the zero [semver.PairConvention]), e.g. "git.3a9f2c1d4e5f.time.20261018T120000Z.dirty".
+const (
+	// KeyRevision is the key of the VCS revision, shortened to 12 characters.
+	KeyRevision = "git"
+	// KeyTime is the key of the VCS commit time in [semver.TimeLayout].
+	KeyTime = "time"
+	// Dirty is the identifier marking a build from a modified working tree.
+	Dirty = "dirty"
+)
+
+// Devel is the version used for a main module without a version, i.e. built from a working tree.
+var Devel = semver.SemVer{PreRelease: "devel"}
+
+func parse(s string) (semver.SemVer, error) {
+	return semver.Parse(strings.TrimPrefix(s, "v"))
+}
+
+// FromBuildInfo returns the main module version of 'bi' with the VCS settings
+// (vcs.revision, vcs.time, vcs.modified) appended as build metadata.
+// The main module version "(devel)" or an empty one is replaced by [Devel].
+func FromBuildInfo(bi *debug.BuildInfo) (semver.SemVer, error) {
+	if bi == nil {
+		return semver.SemVer{}, errors.New("no build information")
+	}
+	v := Devel
+	if s := bi.Main.Version; len(s) > 0 && s != "(devel)" {
+		var err error
+		if v, err = parse(s); err != nil {
+			return semver.SemVer{}, fmt.Errorf("main module version %q: %w", s, err)
+		}
+	}
+	b := v.BuildMetadata()
+	var c semver.PairConvention
+	var err error
+	modified := false
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			rev := s.Value
+			if len(rev) > 12 {
+				rev = rev[:12]
+			}
+			b, err = b.AppendPair(KeyRevision, rev, c)
+		case "vcs.time":
+			var t time.Time
+			if t, err = time.Parse(time.RFC3339, s.Value); err == nil {
+				b, err = b.AppendTime(KeyTime, t, c)
+			}
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+		if err != nil {
+			return semver.SemVer{}, fmt.Errorf("build setting %s=%q: %w", s.Key, s.Value, err)
+		}
+	}
+	// Go 1.24 and later may have already marked the main module version
+	if modified && !slices.Contains(b.Identifiers(), Dirty) {
+		if b, err = b.Append(Dirty); err != nil {
+			return semver.SemVer{}, err
+		}
+	}
+	v.Build = b.String()
+	return v, nil
+}
+
+// Current returns the version of the running binary:
+// [Version] if it is set, or else the version built from [debug.ReadBuildInfo] by [FromBuildInfo].
+func Current() (semver.SemVer, error) {
+	if len(Version) > 0 {
+		v, err := parse(Version)
+		if err != nil {
+			return semver.SemVer{}, fmt.Errorf("link-time version %q: %w", Version, err)
+		}
+		return v, nil
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return semver.SemVer{}, errors.New("no build information")
+	}
+	return FromBuildInfo(bi)
+}
+
+// for tests
+var (
+	stdout io.Writer = os.Stdout
+	exit             = os.Exit
+)
+
+type versionFlag struct {
+	name string
+}
+
+func (*versionFlag) IsBoolFlag() bool { return true }
+
+func (*versionFlag) String() string { return "false" }
+
+func (f *versionFlag) Set(s string) error {
+	set, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if !set {
+		return nil
+	}
+	v, err := Current()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s %s\n", f.name, v)
+	exit(0)
+	return nil
+}
+
+// RegisterFlag defines the boolean "version" flag in 'fs' (in [flag.CommandLine] if 'fs' is nil).
+// When the flag is set, the program prints "'name' [Current]" to standard output and exits with status 0.
+func RegisterFlag(fs *flag.FlagSet, name string) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.Var(&versionFlag{name: name}, "version", "print version and exit")
+}
@@ -0,0 +1,159 @@
+package buildversion
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestFromBuildInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		bi      *debug.BuildInfo
+		want    string
+		wantErr bool
+	}{
+		{name: "nil",
+			wantErr: true,
+		},
+		{name: "tagged",
+			bi:   &debug.BuildInfo{Main: debug.Module{Version: "v1.2.3"}},
+			want: "1.2.3",
+		},
+		{name: "devel",
+			bi:   &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}},
+			want: "0.0.0-devel",
+		},
+		{name: "empty",
+			bi:   &debug.BuildInfo{},
+			want: "0.0.0-devel",
+		},
+		{name: "pseudo-version",
+			bi:   &debug.BuildInfo{Main: debug.Module{Version: "v0.0.0-20261018120000-3a9f2c1d4e5f"}},
+			want: "0.0.0-20261018120000-3a9f2c1d4e5f",
+		},
+		{name: "vcs settings",
+			bi: &debug.BuildInfo{
+				Main: debug.Module{Version: "v1.2.3"},
+				Settings: []debug.BuildSetting{
+					{Key: "-compiler", Value: "gc"},
+					{Key: "vcs", Value: "git"},
+					{Key: "vcs.revision", Value: "3a9f2c1d4e5f60718293a4b5c6d7e8f901234567"},
+					{Key: "vcs.time", Value: "2026-10-18T12:00:00+02:00"},
+					{Key: "vcs.modified", Value: "true"},
+				},
+			},
+			want: "1.2.3+git.3a9f2c1d4e5f.time.20261018T100000Z.dirty",
+		},
+		{name: "not modified",
+			bi: &debug.BuildInfo{
+				Main:     debug.Module{Version: "(devel)"},
+				Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "3a9f2c1"}, {Key: "vcs.modified", Value: "false"}},
+			},
+			want: "0.0.0-devel+git.3a9f2c1",
+		},
+		{name: "already dirty",
+			bi: &debug.BuildInfo{
+				Main:     debug.Module{Version: "v1.2.4-0.20261018120000-3a9f2c1d4e5f+dirty"},
+				Settings: []debug.BuildSetting{{Key: "vcs.modified", Value: "true"}},
+			},
+			want: "1.2.4-0.20261018120000-3a9f2c1d4e5f+dirty",
+		},
+		{name: "invalid version",
+			bi:      &debug.BuildInfo{Main: debug.Module{Version: "v1.2"}},
+			wantErr: true,
+		},
+		{name: "invalid time",
+			bi: &debug.BuildInfo{
+				Main:     debug.Module{Version: "v1.2.3"},
+				Settings: []debug.BuildSetting{{Key: "vcs.time", Value: "yesterday"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromBuildInfo(tt.bi)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromBuildInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("FromBuildInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	defer func(v string) { Version = v }(Version)
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{version: "1.2.3", want: "1.2.3"},
+		{version: "v2.0.0-rc.1+b.5", want: "2.0.0-rc.1+b.5"},
+		{version: "1.2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			Version = tt.version
+			got, err := Current()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Current() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Current() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	Version = ""
+	if _, err := Current(); err != nil {
+		t.Errorf("Current() from build information error = %v", err)
+	}
+}
+
+func TestRegisterFlag(t *testing.T) {
+	defer func(v string) { Version = v }(Version)
+	Version = "1.2.3"
+	defer func(w io.Writer, f func(int)) { stdout, exit = w, f }(stdout, exit)
+	var buf bytes.Buffer
+	stdout = &buf
+	code := -1
+	exit = func(c int) { code = c }
+
+	tests := []struct {
+		args     []string
+		wantOut  string
+		wantCode int
+		wantErr  bool
+	}{
+		{args: []string{"--version"}, wantOut: "tool 1.2.3\n"},
+		{args: []string{"--version=1"}, wantOut: "tool 1.2.3\n"},
+		{args: []string{"--version=TRUE"}, wantOut: "tool 1.2.3\n"},
+		{args: []string{"--version=false"}, wantCode: -1},
+		{args: []string{"--version=0"}, wantCode: -1},
+		{args: []string{"--version=xyz"}, wantCode: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			buf.Reset()
+			code = -1
+			fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			RegisterFlag(fs, "tool")
+			if err := fs.Parse(tt.args); (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := buf.String(); got != tt.wantOut {
+				t.Errorf("output = %q, want %q", got, tt.wantOut)
+			}
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
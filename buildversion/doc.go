@@ -0,0 +1,10 @@
+// Package buildversion provides the version of the running binary as a [semver.SemVer].
+//
+// The version is set at link time:
+//
+//	go build -ldflags "-X github.com/solsw/semver/buildversion.Version=1.2.3"
+//
+// Otherwise it is obtained from [debug.ReadBuildInfo]:
+// the main module version with the VCS revision, time and modification state
+// appended as build metadata (see [FromBuildInfo]).
+package buildversion
@@ -0,0 +1,351 @@
+package calver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solsw/semver"
+)
+
+// CalVer is a calendar version.
+// Fields not present in Scheme are zero.
+type CalVer struct {
+	Scheme Scheme
+	// Year is the full year, also for short year tokens.
+	Year  int
+	Month int
+	Week  int
+	Day   int
+	Micro int64
+	// Modifier is an optional tag following '-' (e.g. "rc.1" or "hotfix.1"),
+	// consisting of valid pre-release identifiers; see [Mapping] for its precedence.
+	Modifier string
+}
+
+// New returns the version of scheme 'sc' for the date of 't' (in the location of 't')
+// with zero micro number.
+func New(sc Scheme, t time.Time) CalVer {
+	c := CalVer{Scheme: sc, Year: t.Year()}
+	if sc.has(fieldWeek) {
+		c.Year, c.Week = t.ISOWeek()
+	}
+	if sc.has(fieldMonth) {
+		c.Month = int(t.Month())
+	}
+	if sc.has(fieldDay) {
+		c.Day = t.Day()
+	}
+	return c
+}
+
+func (c CalVer) value(f field) int64 {
+	switch f {
+	case fieldYear:
+		return int64(c.Year)
+	case fieldMonth:
+		return int64(c.Month)
+	case fieldWeek:
+		return int64(c.Week)
+	case fieldDay:
+		return int64(c.Day)
+	}
+	return c.Micro
+}
+
+func (c *CalVer) set(f field, n int64) {
+	switch f {
+	case fieldYear:
+		c.Year = int(n)
+	case fieldMonth:
+		c.Month = int(n)
+	case fieldWeek:
+		c.Week = int(n)
+	case fieldDay:
+		c.Day = int(n)
+	default:
+		c.Micro = n
+	}
+}
+
+// Parse converts the version string of scheme 'sc' to a [CalVer].
+func Parse(s string, sc Scheme) (CalVer, error) {
+	if len(sc.tokens) == 0 {
+		return CalVer{}, errors.New("invalid scheme")
+	}
+	c := CalVer{Scheme: sc}
+	s, mod, hasMod := strings.Cut(s, "-")
+	if hasMod && len(mod) == 0 {
+		return CalVer{}, errors.New("empty modifier")
+	}
+	c.Modifier = mod
+	ss := strings.Split(s, ".")
+	if len(ss) != len(sc.tokens) {
+		return CalVer{}, fmt.Errorf("calver %q does not match scheme %s", s, sc)
+	}
+	for i, t := range sc.tokens {
+		n, err := parseSegment(ss[i], t)
+		if err != nil {
+			return CalVer{}, err
+		}
+		f, _ := t.field()
+		if f == fieldYear && t != TokenYYYY {
+			n += 2000
+		}
+		c.set(f, n)
+	}
+	if err := c.Valid(); err != nil {
+		return CalVer{}, err
+	}
+	return c, nil
+}
+
+func parseSegment(s string, t Token) (int64, error) {
+	malformed := fmt.Errorf("malformed %s segment %q", t, s)
+	if len(s) == 0 || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, malformed
+	}
+	if t.padded() {
+		if len(s) < 2 || (len(s) > 2 && s[0] == '0') {
+			return 0, malformed
+		}
+	} else if len(s) > 1 && s[0] == '0' {
+		return 0, malformed
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s segment %q: %w", t, s, err)
+	}
+	return n, nil
+}
+
+// Valid reports whether 'c' is a valid version of its scheme
+// (returns the corresponding error otherwise).
+func (c CalVer) Valid() error {
+	sc := c.Scheme
+	if len(sc.tokens) == 0 {
+		return errors.New("invalid scheme")
+	}
+	switch {
+	case sc.tokens[0] == TokenYYYY && c.Year < 1:
+		return errors.New("year is out of range")
+	case sc.tokens[0] != TokenYYYY && c.Year < 2000:
+		return errors.New("short year is before 2000")
+	case sc.has(fieldMonth) && (c.Month < 1 || c.Month > 12):
+		return errors.New("month is out of range")
+	case sc.has(fieldWeek) && (c.Week < 1 || c.Week > isoWeeks(c.Year)):
+		return errors.New("week is out of range")
+	case sc.has(fieldDay) && (c.Day < 1 || c.Day > daysIn(c.Year, c.Month)):
+		return errors.New("day is out of range")
+	case c.Micro < 0:
+		return errors.New("micro is negative")
+	}
+	if len(c.Modifier) > 0 {
+		if _, err := semver.ParsePreReleaseVersion(c.Modifier); err != nil {
+			return fmt.Errorf("malformed modifier (%w)", err)
+		}
+	}
+	return nil
+}
+
+func isoWeeks(year int) int {
+	// December 28 is always in the last week of its ISO year
+	_, w := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return w
+}
+
+func daysIn(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// String implements the [fmt.Stringer] interface.
+func (c CalVer) String() string {
+	var b strings.Builder
+	for i, t := range c.Scheme.tokens {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		f, _ := t.field()
+		n := c.value(f)
+		if f == fieldYear && t != TokenYYYY {
+			n -= 2000
+		}
+		if t.padded() {
+			fmt.Fprintf(&b, "%02d", n)
+		} else {
+			b.WriteString(strconv.FormatInt(n, 10))
+		}
+	}
+	if len(c.Modifier) > 0 {
+		b.WriteByte('-')
+		b.WriteString(c.Modifier)
+	}
+	return b.String()
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (c CalVer) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// The scheme of 'c' must be set beforehand.
+func (c *CalVer) UnmarshalText(text []byte) error {
+	cv, err := Parse(string(text), c.Scheme)
+	if err != nil {
+		return err
+	}
+	*c = cv
+	return nil
+}
+
+// compareDate compares the date segments of 'c' and 'other' of the same scheme.
+func (c CalVer) compareDate(other CalVer) int {
+	for _, t := range c.Scheme.tokens {
+		f, _ := t.field()
+		if f == fieldMicro {
+			continue
+		}
+		if a, b := c.value(f), other.value(f); a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Next returns the version following 'c' released at 'now' (in the location of 'now'):
+// the version of the date of 'now' with zero micro number if the date segments change,
+// or 'c' with the micro number incremented if they do not.
+// The modifier is dropped.
+// Next returns an error if 'now' is earlier than the date of 'c',
+// or if the date segments do not change and the scheme has no MICRO segment.
+func (c CalVer) Next(now time.Time) (CalVer, error) {
+	if err := c.Valid(); err != nil {
+		return CalVer{}, err
+	}
+	n := New(c.Scheme, now)
+	switch n.compareDate(c) {
+	case -1:
+		return CalVer{}, fmt.Errorf("%s is earlier than %s", now.Format(time.DateOnly), c)
+	case 0:
+		if !c.Scheme.has(fieldMicro) {
+			return CalVer{}, fmt.Errorf("scheme %s has no MICRO segment to increment", c.Scheme)
+		}
+		n.Micro = c.Micro + 1
+	}
+	return n, nil
+}
+
+// Mapping configures the mapping between [CalVer] and [semver.SemVer] versions.
+// Its zero value is the mapping used by [CalVer.SemVer] and [FromSemVer].
+//
+// The segments of a version are mapped to major, minor and patch versions
+// (zero if the scheme has fewer segments).
+// A pre-release modifier (e.g. "rc.1") is mapped to the pre-release version, so that
+// "2026.10.18-rc.1" becomes "2026.10.18-rc.1" and precedes "2026.10.18".
+// A post-release modifier (e.g. "hotfix.1") follows the unmodified version:
+// it is mapped to the pre-release version "0.<modifier>" of the next patch version, so that
+// "2026.10.18-hotfix.1" becomes "2026.10.19-0.hotfix.1", following "2026.10.18"
+// and preceding "2026.10.19" and its pre-releases.
+// The mapping preserves the order of versions of the same scheme.
+type Mapping struct {
+	// PreRelease reports whether 'modifier' marks a pre-release; other modifiers mark post-releases.
+	// Pre-release modifiers must not start with the "0" identifier.
+	// nil means that modifiers of a known stage (see [semver.SemVer.Stage]) mark pre-releases.
+	PreRelease func(modifier string) bool
+}
+
+func (m Mapping) isPreRelease(modifier string) bool {
+	if m.PreRelease != nil {
+		return m.PreRelease(modifier)
+	}
+	st := semver.SemVer{PreRelease: modifier}.Stage()
+	return st != semver.StageUnknown && st != semver.StageRelease
+}
+
+// postPrefix is prepended to post-release modifiers; "0" is the lowest pre-release identifier.
+const postPrefix = "0."
+
+// SemVer returns the [semver.SemVer] mapped to 'c' by 'm'.
+func (m Mapping) SemVer(c CalVer) (semver.SemVer, error) {
+	if err := c.Valid(); err != nil {
+		return semver.SemVer{}, err
+	}
+	var nn [3]int64
+	for i, t := range c.Scheme.tokens {
+		f, _ := t.field()
+		nn[i] = c.value(f)
+	}
+	v := semver.SemVer{Major: nn[0], Minor: nn[1], Patch: nn[2], PreRelease: c.Modifier}
+	if len(c.Modifier) == 0 {
+		return v, nil
+	}
+	if m.isPreRelease(c.Modifier) {
+		if c.Modifier == "0" || strings.HasPrefix(c.Modifier, postPrefix) {
+			return semver.SemVer{}, fmt.Errorf("pre-release modifier %q starts with identifier 0", c.Modifier)
+		}
+		return v, nil
+	}
+	if v.Patch == math.MaxInt64 {
+		return semver.SemVer{}, fmt.Errorf("cannot map post-release %s", c)
+	}
+	v.Patch++
+	v.PreRelease = postPrefix + c.Modifier
+	return v, nil
+}
+
+// FromSemVer returns the version of scheme 'sc' mapped to 'v' by 'm'.
+func (m Mapping) FromSemVer(v semver.SemVer, sc Scheme) (CalVer, error) {
+	if err := semver.Valid(v); err != nil {
+		return CalVer{}, err
+	}
+	if len(sc.tokens) == 0 {
+		return CalVer{}, errors.New("invalid scheme")
+	}
+	if len(v.Build) > 0 {
+		return CalVer{}, errors.New("build metadata is not supported")
+	}
+	mod := v.PreRelease
+	if post, ok := strings.CutPrefix(mod, postPrefix); ok && v.Patch > 0 {
+		if m.isPreRelease(post) {
+			return CalVer{}, fmt.Errorf("%s is not mapped from a post-release", v)
+		}
+		v.Patch--
+		mod = post
+	} else if len(mod) > 0 && !m.isPreRelease(mod) {
+		return CalVer{}, fmt.Errorf("%s is not mapped from a pre-release", v)
+	}
+	nn := []int64{v.Major, v.Minor, v.Patch}
+	if len(sc.tokens) < 3 && v.Patch != 0 || len(sc.tokens) < 2 && v.Minor != 0 {
+		return CalVer{}, fmt.Errorf("%s has more segments than scheme %s", v, sc)
+	}
+	c := CalVer{Scheme: sc, Modifier: mod}
+	for i, t := range sc.tokens {
+		f, _ := t.field()
+		if f != fieldMicro && nn[i] > 1<<31-1 {
+			return CalVer{}, fmt.Errorf("%s segment %d is out of range", t, nn[i])
+		}
+		c.set(f, nn[i])
+	}
+	if err := c.Valid(); err != nil {
+		return CalVer{}, err
+	}
+	return c, nil
+}
+
+// SemVer returns the [semver.SemVer] mapped to 'c' by the zero [Mapping]:
+// "2026.10.18-rc.1" precedes "2026.10.18", which precedes "2026.10.18-hotfix.1".
+func (c CalVer) SemVer() (semver.SemVer, error) {
+	return Mapping{}.SemVer(c)
+}
+
+// FromSemVer returns the version of scheme 'sc' mapped to 'v' by [CalVer.SemVer].
+func FromSemVer(v semver.SemVer, sc Scheme) (CalVer, error) {
+	return Mapping{}.FromSemVer(v, sc)
+}
@@ -0,0 +1,254 @@
+package calver
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solsw/semver"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s       string
+		scheme  string
+		want    CalVer
+		wantErr bool
+	}{
+		{s: "2026.10.3", scheme: "YYYY.MM.MICRO", want: CalVer{Year: 2026, Month: 10, Micro: 3}},
+		{s: "26.04.07", scheme: "YY.0M.0D", want: CalVer{Year: 2026, Month: 4, Day: 7}},
+		{s: "26.04", scheme: "YY.0M", want: CalVer{Year: 2026, Month: 4}},
+		{s: "2026.42", scheme: "YYYY.WW", want: CalVer{Year: 2026, Week: 42}},
+		{s: "2026.10.18-hotfix.1", scheme: "YYYY.MM.DD", want: CalVer{Year: 2026, Month: 10, Day: 18, Modifier: "hotfix.1"}},
+		{s: "106.1.0", scheme: "YY.MM.MICRO", want: CalVer{Year: 2106, Month: 1}},
+		{s: "2026.1", scheme: "YYYY.0M", wantErr: true},
+		{s: "2026.010", scheme: "YYYY.0M", wantErr: true},
+		{s: "2026.01", scheme: "YYYY.MM", wantErr: true},
+		{s: "2026.13", scheme: "YYYY.MM", wantErr: true},
+		{s: "2026.2.29", scheme: "YYYY.MM.DD", wantErr: true},
+		{s: "2028.2.29", scheme: "YYYY.MM.DD", want: CalVer{Year: 2028, Month: 2, Day: 29}},
+		{s: "2026.53", scheme: "YYYY.WW", want: CalVer{Year: 2026, Week: 53}},
+		{s: "2025.53", scheme: "YYYY.WW", wantErr: true},
+		{s: "2026.10", scheme: "YYYY.MM.MICRO", wantErr: true},
+		{s: "2026.10.01", scheme: "YYYY.MM.MICRO", wantErr: true},
+		{s: "2026.10.x", scheme: "YYYY.MM.MICRO", wantErr: true},
+		{s: "2026.10.1-", scheme: "YYYY.MM.MICRO", wantErr: true},
+		{s: "2026.10.1-a..b", scheme: "YYYY.MM.MICRO", wantErr: true},
+		{s: "0.10.1", scheme: "YYYY.MM.MICRO", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s+" "+tt.scheme, func(t *testing.T) {
+			sc := MustParseScheme(tt.scheme)
+			got, err := Parse(tt.s, sc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			tt.want.Scheme = sc
+			if got.String() != tt.s || got.Year != tt.want.Year || got.Month != tt.want.Month || got.Week != tt.want.Week ||
+				got.Day != tt.want.Day || got.Micro != tt.want.Micro || got.Modifier != tt.want.Modifier {
+				t.Errorf("Parse() = %+v (%v), want %+v", got, got, tt.want)
+			}
+		})
+	}
+	if _, err := Parse("2026.10", Scheme{}); err == nil {
+		t.Errorf("Parse() with zero scheme error = nil")
+	}
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 12, 0, 0, 0, time.UTC)
+}
+
+func TestCalVer_Next(t *testing.T) {
+	tests := []struct {
+		v       string
+		scheme  string
+		now     time.Time
+		want    string
+		wantErr bool
+	}{
+		{v: "2026.10.3", scheme: "YYYY.MM.MICRO", now: date(2026, 10, 18), want: "2026.10.4"},
+		{v: "2026.10.3", scheme: "YYYY.MM.MICRO", now: date(2026, 11, 1), want: "2026.11.0"},
+		{v: "2026.10.3-rc.1", scheme: "YYYY.MM.MICRO", now: date(2026, 10, 18), want: "2026.10.4"},
+		{v: "26.10.17", scheme: "YY.0M.0D", now: date(2026, 10, 18), want: "26.10.18"},
+		{v: "26.10.18", scheme: "YY.0M.0D", now: date(2026, 10, 18), wantErr: true},
+		{v: "2026.10.18.2", scheme: "YYYY.MM.DD", now: date(2026, 10, 18), wantErr: true},
+		{v: "2026.42", scheme: "YYYY.WW", now: date(2026, 10, 26), want: "2026.44"},
+		{v: "2026.53", scheme: "YYYY.WW", now: date(2027, 1, 1), wantErr: true},
+		{v: "2020.53", scheme: "YYYY.WW", now: date(2021, 1, 3), wantErr: true},
+		{v: "2020.53", scheme: "YYYY.WW", now: date(2021, 1, 4), want: "2021.1"},
+		{v: "2026.10.3", scheme: "YYYY.MM.MICRO", now: date(2026, 9, 30), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v+" "+tt.now.Format(time.DateOnly), func(t *testing.T) {
+			c, err := Parse(tt.v, MustParseScheme(tt.scheme))
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			got, err := c.Next(tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Next() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalVer_SemVer(t *testing.T) {
+	tests := []struct {
+		v      string
+		scheme string
+		want   string
+	}{
+		{v: "2026.10.3", scheme: "YYYY.MM.MICRO", want: "2026.10.3"},
+		{v: "26.04.07", scheme: "YY.0M.0D", want: "2026.4.7"},
+		{v: "2026.42", scheme: "YYYY.WW", want: "2026.42.0"},
+		{v: "2026.10.18-hotfix.1", scheme: "YYYY.MM.DD", want: "2026.10.19-0.hotfix.1"},
+		{v: "2026.10.18-rc.1", scheme: "YYYY.MM.DD", want: "2026.10.18-rc.1"},
+		{v: "2026.42-hotfix", scheme: "YYYY.WW", want: "2026.42.1-0.hotfix"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			sc := MustParseScheme(tt.scheme)
+			c, err := Parse(tt.v, sc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.SemVer()
+			if err != nil {
+				t.Fatalf("SemVer() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("SemVer() = %v, want %v", got, tt.want)
+			}
+			back, err := FromSemVer(got, sc)
+			if err != nil {
+				t.Fatalf("FromSemVer() error = %v", err)
+			}
+			if back.String() != tt.v {
+				t.Errorf("FromSemVer() = %v, want %v", back, tt.v)
+			}
+		})
+	}
+}
+
+func TestCalVer_SemVer_Order(t *testing.T) {
+	sc := MustParseScheme("YY.0M.0D")
+	ss := []string{"25.12.31", "25.12.31-hotfix.1", "25.12.31-hotfix.2", "26.01.01-beta", "26.01.01-rc.1", "26.01.01",
+		"26.01.01-hotfix", "26.01.02", "26.01.10", "26.02.01", "106.01.01"}
+	var vv []semver.SemVer
+	for _, s := range ss {
+		c, err := Parse(s, sc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := c.SemVer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		vv = append(vv, v)
+	}
+	if !slices.IsSortedFunc(vv, func(a, b semver.SemVer) int { r, _ := semver.Compare(a, b); return r }) {
+		t.Errorf("SemVer() does not preserve order: %v", vv)
+	}
+}
+
+func TestFromSemVer(t *testing.T) {
+	tests := []struct {
+		v      semver.SemVer
+		scheme string
+	}{
+		{v: semver.SemVer{Major: 2026, Minor: 10, Patch: 1}, scheme: "YYYY.WW"},
+		{v: semver.SemVer{Major: 2026, Minor: 13}, scheme: "YYYY.MM.MICRO"},
+		{v: semver.SemVer{Major: 2026, Minor: 10, Build: "b"}, scheme: "YYYY.MM.MICRO"},
+		{v: semver.SemVer{Major: 1999, Minor: 10}, scheme: "YY.MM"},
+		{v: semver.SemVer{Major: 1 << 40, Minor: 10}, scheme: "YYYY.MM"},
+		{v: semver.SemVer{Major: -1}, scheme: "YYYY.MM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v.String(), func(t *testing.T) {
+			if got, err := FromSemVer(tt.v, MustParseScheme(tt.scheme)); err == nil {
+				t.Errorf("FromSemVer() = %v, want error", got)
+			}
+		})
+	}
+}
+
+func TestCalVer_MarshalText(t *testing.T) {
+	c := CalVer{Scheme: SchemeYYYYMMMICRO}
+	if err := json.Unmarshal([]byte(`"2026.10.3"`), &c); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `"2026.10.3"`; got != want {
+		t.Errorf("Marshal() = %v, want %v", got, want)
+	}
+	var noScheme CalVer
+	if err := json.Unmarshal([]byte(`"2026.10.3"`), &noScheme); err == nil {
+		t.Errorf("Unmarshal() without scheme error = nil")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if got, want := New(SchemeYY0M0D, date(2026, 4, 7)).String(), "26.04.07"; got != want {
+		t.Errorf("New() = %v, want %v", got, want)
+	}
+	if got, want := New(SchemeYYYYWW, date(2027, 1, 1)).String(), "2026.53"; got != want {
+		t.Errorf("New() = %v, want %v", got, want)
+	}
+}
+
+func TestMapping(t *testing.T) {
+	sc := MustParseScheme("YYYY.MM.MICRO")
+	m := Mapping{PreRelease: func(modifier string) bool { return strings.HasPrefix(modifier, "pre") }}
+	tests := []struct {
+		v       string
+		want    string
+		wantErr bool
+	}{
+		{v: "2026.10.3-preview", want: "2026.10.3-preview"},
+		{v: "2026.10.3-rc.1", want: "2026.10.4-0.rc.1"},
+		{v: "2026.10.3", want: "2026.10.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			c, err := Parse(tt.v, sc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := m.SemVer(c)
+			if err != nil {
+				t.Fatalf("SemVer() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("SemVer() = %v, want %v", got, tt.want)
+			}
+			back, err := m.FromSemVer(got, sc)
+			if err != nil || back.String() != tt.v {
+				t.Errorf("FromSemVer() = %v, %v, want %v", back, err, tt.v)
+			}
+		})
+	}
+	zero := Mapping{PreRelease: func(string) bool { return true }}
+	if got, err := zero.SemVer(CalVer{Scheme: sc, Year: 2026, Month: 10, Modifier: "0.x"}); err == nil {
+		t.Errorf("SemVer() = %v, want error", got)
+	}
+	for _, v := range []string{"2026.10.3-x", "2026.10.4-0.rc"} {
+		if got, err := FromSemVer(semver.MustParse(v), sc); err == nil {
+			t.Errorf("FromSemVer(%s) = %v, want error", v, got)
+		}
+	}
+}
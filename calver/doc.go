@@ -0,0 +1,12 @@
+// Package calver implements [Calendar Versioning] with configurable schemes
+// and a mapping to [semver.SemVer].
+//
+// A [Scheme] such as "YYYY.MM.MICRO", "YY.0M.0D" or "YYYY.WW" describes the segments of a version;
+// a [CalVer] holds the date, the micro number and an optional modifier ("2026.10.18-hotfix.1").
+// [CalVer.SemVer] maps a version to a spec-valid [semver.SemVer] preserving order,
+// so CalVer versions can be compared with [semver.Compare] and stored as text;
+// pre-release modifiers ("rc.1") precede and post-release modifiers ("hotfix.1") follow the unmodified version
+// (see [Mapping]).
+//
+// [Calendar Versioning]: https://calver.org/
+package calver
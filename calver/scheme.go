@@ -0,0 +1,147 @@
+package calver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Token is a segment of a [Scheme], see https://calver.org/#scheme.
+type Token string
+
+// Tokens.
+const (
+	// TokenYYYY is the full year: 2006, 2016, 2106.
+	TokenYYYY Token = "YYYY"
+	// TokenYY is the short year (year - 2000): 6, 16, 106.
+	TokenYY Token = "YY"
+	// Token0Y is the zero-padded short year: 06, 16, 106.
+	Token0Y Token = "0Y"
+	// TokenMM is the month: 1, 2 ... 11, 12.
+	TokenMM Token = "MM"
+	// Token0M is the zero-padded month: 01, 02 ... 11, 12.
+	Token0M Token = "0M"
+	// TokenWW is the ISO 8601 week of the year: 1, 2 ... 52, 53.
+	// The year of a scheme with a week is the ISO 8601 week-numbering year.
+	TokenWW Token = "WW"
+	// Token0W is the zero-padded ISO 8601 week of the year: 01, 02 ... 52, 53.
+	Token0W Token = "0W"
+	// TokenDD is the day of the month: 1, 2 ... 30, 31.
+	TokenDD Token = "DD"
+	// Token0D is the zero-padded day of the month: 01, 02 ... 30, 31.
+	Token0D Token = "0D"
+	// TokenMICRO is the number of the release within the date, starting with 0.
+	TokenMICRO Token = "MICRO"
+)
+
+// field is the part of a version a token holds.
+type field int
+
+const (
+	fieldYear field = iota
+	fieldMonth
+	fieldWeek
+	fieldDay
+	fieldMicro
+)
+
+func (t Token) field() (field, bool) {
+	switch t {
+	case TokenYYYY, TokenYY, Token0Y:
+		return fieldYear, true
+	case TokenMM, Token0M:
+		return fieldMonth, true
+	case TokenWW, Token0W:
+		return fieldWeek, true
+	case TokenDD, Token0D:
+		return fieldDay, true
+	case TokenMICRO:
+		return fieldMicro, true
+	}
+	return 0, false
+}
+
+func (t Token) padded() bool {
+	return strings.HasPrefix(string(t), "0")
+}
+
+// Scheme is a calendar versioning scheme: dot-separated tokens.
+// A scheme starts with a year token, has at most three segments (so that versions map to [semver.SemVer]),
+// has a week or a month (optionally followed by a day) or neither, and may end with MICRO.
+// Scheme's zero value is not a valid scheme.
+type Scheme struct {
+	tokens []Token
+}
+
+// Common schemes.
+var (
+	SchemeYYYYMMMICRO = MustParseScheme("YYYY.MM.MICRO")
+	SchemeYY0M0D      = MustParseScheme("YY.0M.0D")
+	SchemeYYYYWW      = MustParseScheme("YYYY.WW")
+)
+
+// ParseScheme converts the scheme string (e.g. "YYYY.0M.MICRO") to a [Scheme].
+func ParseScheme(s string) (Scheme, error) {
+	ss := strings.Split(s, ".")
+	if len(ss) > 3 {
+		return Scheme{}, errors.New("scheme has more than three segments")
+	}
+	tokens := make([]Token, len(ss))
+	fields := make([]field, len(ss))
+	for i, tok := range ss {
+		f, ok := Token(tok).field()
+		if !ok {
+			return Scheme{}, fmt.Errorf("unknown scheme token %q", tok)
+		}
+		if slices.Contains(fields[:i], f) {
+			return Scheme{}, fmt.Errorf("duplicate scheme token %q", tok)
+		}
+		tokens[i], fields[i] = Token(tok), f
+	}
+	switch {
+	case fields[0] != fieldYear:
+		return Scheme{}, errors.New("scheme does not start with year")
+	case len(fields) == 1:
+		return Scheme{}, errors.New("scheme has year only")
+	case slices.Contains(fields, fieldWeek) && (slices.Contains(fields, fieldMonth) || slices.Contains(fields, fieldDay)):
+		return Scheme{}, errors.New("scheme has both week and month or day")
+	case slices.Contains(fields, fieldDay) && fields[1] != fieldMonth:
+		return Scheme{}, errors.New("scheme has day without preceding month")
+	case slices.Index(fields, fieldMicro) > 0 && slices.Index(fields, fieldMicro) != len(fields)-1:
+		return Scheme{}, errors.New("scheme has MICRO before the last segment")
+	}
+	return Scheme{tokens: tokens}, nil
+}
+
+// MustParseScheme is like [ParseScheme] but panics if the scheme string cannot be parsed.
+func MustParseScheme(s string) Scheme {
+	sc, err := ParseScheme(s)
+	if err != nil {
+		panic(err)
+	}
+	return sc
+}
+
+// String implements the [fmt.Stringer] interface.
+func (sc Scheme) String() string {
+	ss := make([]string, len(sc.tokens))
+	for i, t := range sc.tokens {
+		ss[i] = string(t)
+	}
+	return strings.Join(ss, ".")
+}
+
+// Tokens returns the tokens of 'sc'.
+func (sc Scheme) Tokens() []Token {
+	return slices.Clone(sc.tokens)
+}
+
+func (sc Scheme) has(f field) bool {
+	for _, t := range sc.tokens {
+		if tf, _ := t.field(); tf == f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package calver
+
+import (
+	"testing"
+)
+
+func TestParseScheme(t *testing.T) {
+	tests := []struct {
+		s       string
+		wantErr bool
+	}{
+		{s: "YYYY.MM.MICRO"},
+		{s: "YY.0M.0D"},
+		{s: "YYYY.WW"},
+		{s: "0Y.0W.MICRO"},
+		{s: "YYYY.MICRO"},
+		{s: "YYYY.MM"},
+		{s: "", wantErr: true},
+		{s: "YYYY", wantErr: true},
+		{s: "yyyy.mm", wantErr: true},
+		{s: "MM.YYYY", wantErr: true},
+		{s: "YYYY.YY", wantErr: true},
+		{s: "YYYY.MM.DD.MICRO", wantErr: true},
+		{s: "YYYY.WW.DD", wantErr: true},
+		{s: "YYYY.DD", wantErr: true},
+		{s: "YYYY.MICRO.MM", wantErr: true},
+		{s: "YYYY..MM", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseScheme(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseScheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.s {
+				t.Errorf("ParseScheme().String() = %v, want %v", got, tt.s)
+			}
+		})
+	}
+}
+
+func TestMustParseScheme(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParseScheme() did not panic")
+		}
+	}()
+	MustParseScheme("MICRO")
+}
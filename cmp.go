@@ -0,0 +1,96 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cmp compares 'a' with 'b' by [precedence] like [Compare], but never fails,
+// so it can be used with [slices.SortFunc], [slices.BinarySearchFunc] and similar functions.
+// An invalid version is less than any valid version; invalid versions are ordered
+// by their [SemVer.String] forms.
+// Cmp returns -1 if 'a' is less than 'b', 0 if 'a' is equal to 'b', 1 if 'a' is more than 'b'.
+//
+// [precedence]: https://semver.org/#spec-item-11
+func Cmp(a, b SemVer) int {
+	aValid, bValid := Valid(a) == nil, Valid(b) == nil
+	switch {
+	case aValid && bValid:
+		return compareValid(a, b)
+	case aValid:
+		return 1
+	case bValid:
+		return -1
+	}
+	return strings.Compare(a.String(), b.String())
+}
+
+// CmpStrict is like [Cmp], but compares in the total order of [CompareStrict],
+// returning 0 only for [Identical] versions.
+func CmpStrict(a, b SemVer) int {
+	aValid, bValid := Valid(a) == nil, Valid(b) == nil
+	switch {
+	case aValid && bValid:
+		return compareStrictValid(a, b)
+	case aValid:
+		return 1
+	case bValid:
+		return -1
+	}
+	if r := strings.Compare(a.String(), b.String()); r != 0 {
+		return r
+	}
+	// different invalid versions may have the same string,
+	// e.g. pre-release version "a+b" and pre-release version "a" with build metadata "b"
+	if r := strings.Compare(a.PreRelease, b.PreRelease); r != 0 {
+		return r
+	}
+	return strings.Compare(a.Build, b.Build)
+}
+
+// MustParse is like [Parse] but panics if the version string cannot be parsed.
+func MustParse(s string) SemVer {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Max returns the highest of 'v' and 'vv' by [Cmp].
+// Of several versions of the highest precedence the first one is returned.
+func Max(v SemVer, vv ...SemVer) SemVer {
+	for _, x := range vv {
+		if Cmp(x, v) > 0 {
+			v = x
+		}
+	}
+	return v
+}
+
+// Min returns the lowest of 'v' and 'vv' by [Cmp].
+// Of several versions of the lowest precedence the first one is returned.
+func Min(v SemVer, vv ...SemVer) SemVer {
+	for _, x := range vv {
+		if Cmp(x, v) < 0 {
+			v = x
+		}
+	}
+	return v
+}
+
+// Clamp returns 'v' limited to the interval from 'lo' to 'hi' by [Cmp]:
+// 'lo' if 'v' is less than 'lo', 'hi' if 'v' is more than 'hi', 'v' otherwise.
+// If 'lo' is more than 'hi', Clamp panics.
+func Clamp(v, lo, hi SemVer) SemVer {
+	if Cmp(lo, hi) > 0 {
+		panic(fmt.Sprintf("semver: Clamp: %v is more than %v", lo, hi))
+	}
+	switch {
+	case Cmp(v, lo) < 0:
+		return lo
+	case Cmp(v, hi) > 0:
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,130 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCmp(t *testing.T) {
+	invalid1 := SemVer{Major: -1}
+	invalid2 := SemVer{Major: 1, PreRelease: "01"}
+	tests := []struct {
+		name       string
+		a, b       SemVer
+		want       int
+		wantStrict int
+	}{
+		{name: "equal", a: MustParse("1.0.0"), b: MustParse("1.0.0"), want: 0, wantStrict: 0},
+		{name: "less", a: MustParse("1.0.0-rc.1"), b: MustParse("1.0.0"), want: -1, wantStrict: -1},
+		{name: "more", a: MustParse("1.10.0"), b: MustParse("1.9.0"), want: 1, wantStrict: 1},
+		{name: "build", a: MustParse("1.0.0+b"), b: MustParse("1.0.0+a"), want: 0, wantStrict: 1},
+		{name: "invalid first", a: invalid1, b: SemVer{}, want: -1, wantStrict: -1},
+		{name: "valid after invalid", a: SemVer{}, b: invalid2, want: 1, wantStrict: 1},
+		{name: "invalid by string", a: invalid1, b: invalid2, want: -1, wantStrict: -1},
+		{name: "invalid equal", a: invalid2, b: invalid2, want: 0, wantStrict: 0},
+		{name: "invalid same string",
+			a:    SemVer{Major: -1, PreRelease: "a+b"},
+			b:    SemVer{Major: -1, PreRelease: "a", Build: "b"},
+			want: 0, wantStrict: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cmp(tt.a, tt.b); got != tt.want {
+				t.Errorf("Cmp() = %d, want %d", got, tt.want)
+			}
+			if got := Cmp(tt.b, tt.a); got != -tt.want {
+				t.Errorf("Cmp() reversed = %d, want %d", got, -tt.want)
+			}
+			if got := CmpStrict(tt.a, tt.b); got != tt.wantStrict {
+				t.Errorf("CmpStrict() = %d, want %d", got, tt.wantStrict)
+			}
+			if got := CmpStrict(tt.b, tt.a); got != -tt.wantStrict {
+				t.Errorf("CmpStrict() reversed = %d, want %d", got, -tt.wantStrict)
+			}
+		})
+	}
+}
+
+func TestCmp_Slices(t *testing.T) {
+	vv := []SemVer{
+		MustParse("2.0.0"), {Major: -1}, MustParse("1.0.0-alpha"), MustParse("1.0.0"),
+		{Minor: -2}, MustParse("1.0.0-alpha.1"), MustParse("0.9.0"),
+	}
+	slices.SortFunc(vv, Cmp)
+	var ss []string
+	for _, v := range vv {
+		ss = append(ss, v.String())
+	}
+	if got, want := strings.Join(ss, " "), "-1.0.0 0.-2.0 0.9.0 1.0.0-alpha 1.0.0-alpha.1 1.0.0 2.0.0"; got != want {
+		t.Errorf("SortFunc(Cmp) = %v, want %v", got, want)
+	}
+	if i, found := slices.BinarySearchFunc(vv, MustParse("1.0.0+b"), Cmp); !found || i != 5 {
+		t.Errorf("BinarySearchFunc(Cmp) = %d, %v", i, found)
+	}
+	if got := slices.MaxFunc(vv, Cmp); got != MustParse("2.0.0") {
+		t.Errorf("MaxFunc(Cmp) = %v", got)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if got := MustParse("1.2.3-rc.1+b"); fmt.Sprintf("%v", got) != "1.2.3-rc.1+b" {
+		t.Errorf("MustParse() = %v", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParse() did not panic")
+		}
+	}()
+	MustParse("1.2")
+}
+
+func TestMax_Min(t *testing.T) {
+	vv := []SemVer{MustParse("1.0.0+a"), MustParse("2.0.0-rc.1"), MustParse("0.1.0"), MustParse("2.0.0-rc.1+b"), MustParse("0.1.0+b")}
+	if got := Max(vv[0], vv[1:]...); fmt.Sprintf("%v", got) != "2.0.0-rc.1" {
+		t.Errorf("Max() = %v", got)
+	}
+	if got := Min(vv[0], vv[1:]...); fmt.Sprintf("%v", got) != "0.1.0" {
+		t.Errorf("Min() = %v", got)
+	}
+	if got := Min(vv[0], SemVer{Major: -1}); got.Major != -1 {
+		t.Errorf("Min() with invalid version = %v", got)
+	}
+	if got := Max(vv[0]); got != vv[0] {
+		t.Errorf("Max() of one version = %v", got)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	lo, hi := MustParse("1.2.0"), MustParse("1.9.0")
+	tests := []struct {
+		v    string
+		want string
+	}{
+		{v: "1.0.0", want: "1.2.0"},
+		{v: "1.2.0-rc.1", want: "1.2.0"},
+		{v: "1.2.0+b", want: "1.2.0+b"},
+		{v: "1.5.0", want: "1.5.0"},
+		{v: "1.9.0", want: "1.9.0"},
+		{v: "1.9.1-0", want: "1.9.0"},
+		{v: "2.0.0", want: "1.9.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			if got := Clamp(MustParse(tt.v), lo, hi); fmt.Sprintf("%v", got) != tt.want {
+				t.Errorf("Clamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := Clamp(SemVer{Major: -1}, lo, hi); got != lo {
+		t.Errorf("Clamp() of invalid version = %v, want %v", got, lo)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Clamp() with lo > hi did not panic")
+		}
+	}()
+	Clamp(lo, hi, lo)
+}
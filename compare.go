@@ -15,21 +15,26 @@ func Compare(sv1, sv2 SemVer) (int, error) {
 	if err := Valid(sv2); err != nil {
 		return 0, err
 	}
+	return compareValid(sv1, sv2), nil
+}
+
+// compareValid compares already validated 'sv1' and 'sv2'.
+func compareValid(sv1, sv2 SemVer) int {
 	// https://semver.org/#spec-item-11
 	if sv1.Major != sv2.Major {
-		return boolToCompareResult(sv1.Major > sv2.Major), nil
+		return boolToCompareResult(sv1.Major > sv2.Major)
 	}
 	if sv1.Minor != sv2.Minor {
-		return boolToCompareResult(sv1.Minor > sv2.Minor), nil
+		return boolToCompareResult(sv1.Minor > sv2.Minor)
 	}
 	if sv1.Patch != sv2.Patch {
-		return boolToCompareResult(sv1.Patch > sv2.Patch), nil
+		return boolToCompareResult(sv1.Patch > sv2.Patch)
 	}
-	return comparePreRelease(sv1.PreRelease, sv2.PreRelease), nil
+	return comparePreRelease(sv1.PreRelease, sv2.PreRelease)
 }
 
 // Less reports whether 'sv1' is [less] than 'sv2'.
-// If 'sv1' or/and 'sv2' is/are invalid, Less panics.
+// If 'sv1' or/and 'sv2' is/are invalid, Less panics; use [Cmp] to order invalid versions.
 //
 // [less]: https://semver.org/#spec-item-11
 func Less(sv1, sv2 SemVer) bool {
@@ -47,6 +52,19 @@ func boolToCompareResult(b bool) int {
 	return -1
 }
 
+// compareNumeric compares decimal digit strings without leading zeros numerically.
+// The longer digit string is the larger number; equal lengths compare lexically.
+// This avoids integer overflow.
+func compareNumeric(n1, n2 string) int {
+	if len(n1) != len(n2) {
+		return boolToCompareResult(len(n1) > len(n2))
+	}
+	if n1 != n2 {
+		return boolToCompareResult(n1 > n2)
+	}
+	return 0
+}
+
 func comparePreRelease(pr1, pr2 string) int {
 	if len(pr1) == 0 && len(pr2) == 0 {
 		return 0
@@ -57,26 +75,16 @@ func comparePreRelease(pr1, pr2 string) int {
 	if len(pr1) == 0 && len(pr2) > 0 {
 		return 1
 	}
-	ids1 := strings.Split(pr1, ".")
-	ids2 := strings.Split(pr2, ".")
-	shorter := len(ids1)
-	if len(ids2) < shorter {
-		shorter = len(ids2)
-	}
-	for i := 0; i < shorter; i++ {
-		id1, id2 := ids1[i], ids2[i]
+	// identifiers are iterated without splitting to avoid allocations
+	for {
+		id1, rest1, more1 := strings.Cut(pr1, ".")
+		id2, rest2, more2 := strings.Cut(pr2, ".")
 		num1, num2 := isNumeric(id1), isNumeric(id2)
 		switch {
 		case num1 && num2:
 			// https://semver.org/#spec-item-11 (4.1): compare numerically.
-			// Identifiers are validated to have no leading zeros, so the
-			// longer digit string is the larger number; equal lengths
-			// compare lexically. This avoids integer overflow.
-			if len(id1) != len(id2) {
-				return boolToCompareResult(len(id1) > len(id2))
-			}
-			if id1 != id2 {
-				return boolToCompareResult(id1 > id2)
+			if r := compareNumeric(id1, id2); r != 0 {
+				return r
 			}
 		case num1 && !num2:
 			// https://semver.org/#spec-item-11 (4.3)
@@ -89,13 +97,16 @@ func comparePreRelease(pr1, pr2 string) int {
 				return boolToCompareResult(id1 > id2)
 			}
 		}
+		// https://semver.org/#spec-item-11 (4.4)
+		switch {
+		case more1 && more2:
+			pr1, pr2 = rest1, rest2
+		case more1:
+			return 1
+		case more2:
+			return -1
+		default:
+			return 0
+		}
 	}
-	// https://semver.org/#spec-item-11 (4.4)
-	if len(ids1) < len(ids2) {
-		return -1
-	}
-	if len(ids1) > len(ids2) {
-		return 1
-	}
-	return 0
 }
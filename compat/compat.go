@@ -0,0 +1,245 @@
+package compat
+
+import (
+	"fmt"
+
+	"github.com/solsw/semver"
+)
+
+// Verdict is the outcome of evaluating a client version.
+type Verdict int
+
+const (
+	// OK means that the client version is supported and up to date.
+	OK Verdict = iota
+	// UpdateRecommended means that the client version is supported, but an update is recommended.
+	UpdateRecommended
+	// UpdateRequired means that the client version is no longer supported.
+	UpdateRequired
+)
+
+var verdictNames = []string{"ok", "update_recommended", "update_required"}
+
+// String implements the [fmt.Stringer] interface.
+func (v Verdict) String() string {
+	if v < 0 || int(v) >= len(verdictNames) {
+		return fmt.Sprintf("Verdict(%d)", int(v))
+	}
+	return verdictNames[v]
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (v Verdict) MarshalText() ([]byte, error) {
+	if v < 0 || int(v) >= len(verdictNames) {
+		return nil, fmt.Errorf("unknown verdict %d", int(v))
+	}
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for i, name := range verdictNames {
+		if string(text) == name {
+			*v = Verdict(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// PreReleasePolicy determines how pre-release client versions are evaluated.
+type PreReleasePolicy int
+
+const (
+	// PreReleaseStrict compares pre-release versions by precedence,
+	// so "2.0.0-beta" is below the minimum version "2.0.0".
+	PreReleaseStrict PreReleasePolicy = iota
+	// PreReleaseAsRelease compares pre-release versions as their release versions,
+	// so "2.0.0-beta" satisfies the minimum version "2.0.0".
+	PreReleaseAsRelease
+	// PreReleaseAllow accepts all pre-release versions that are not blocked.
+	PreReleaseAllow
+	// PreReleaseDeny requires an update of all pre-release versions.
+	PreReleaseDeny
+)
+
+var preReleaseNames = []string{"strict", "as_release", "allow", "deny"}
+
+// String implements the [fmt.Stringer] interface.
+func (p PreReleasePolicy) String() string {
+	if p < 0 || int(p) >= len(preReleaseNames) {
+		return fmt.Sprintf("PreReleasePolicy(%d)", int(p))
+	}
+	return preReleaseNames[p]
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (p PreReleasePolicy) MarshalText() ([]byte, error) {
+	if p < 0 || int(p) >= len(preReleaseNames) {
+		return nil, fmt.Errorf("unknown pre-release policy %d", int(p))
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (p *PreReleasePolicy) UnmarshalText(text []byte) error {
+	for i, name := range preReleaseNames {
+		if string(text) == name {
+			*p = PreReleasePolicy(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown pre-release policy %q", text)
+}
+
+// Rules are the versions of a platform's clients. Nil fields are not set.
+type Rules struct {
+	// Minimum is the minimum supported version; lower versions require an update.
+	Minimum *semver.SemVer `json:"minimum,omitempty"`
+	// Recommended is the minimum recommended version; lower versions are recommended to update.
+	Recommended *semver.SemVer `json:"recommended,omitempty"`
+	// Latest is the version to update to.
+	Latest *semver.SemVer `json:"latest,omitempty"`
+	// Blocked are versions that require an update regardless of other rules.
+	Blocked *semver.Range `json:"blocked,omitempty"`
+}
+
+// target returns a copy of the highest of the set versions of 'r'.
+func (r Rules) target() *semver.SemVer {
+	var t *semver.SemVer
+	for _, v := range []*semver.SemVer{r.Minimum, r.Recommended, r.Latest} {
+		if v != nil && (t == nil || semver.Cmp(*t, *v) < 0) {
+			t = v
+		}
+	}
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+// validate checks that the versions of 'r' are valid and ordered
+// and that the target version is not blocked.
+func (r Rules) validate() error {
+	names := []string{"minimum", "recommended", "latest"}
+	var prev *semver.SemVer
+	var prevName string
+	for i, v := range []*semver.SemVer{r.Minimum, r.Recommended, r.Latest} {
+		if v == nil {
+			continue
+		}
+		if err := semver.Valid(*v); err != nil {
+			return fmt.Errorf("%s version: %w", names[i], err)
+		}
+		if prev != nil && semver.Less(*v, *prev) {
+			return fmt.Errorf("%s version %v is lower than %s version %v", names[i], *v, prevName, *prev)
+		}
+		prev, prevName = v, names[i]
+	}
+	if t := r.target(); t != nil && r.Blocked != nil && r.Blocked.Contains(*t) {
+		return fmt.Errorf("target version %v is blocked", *t)
+	}
+	return nil
+}
+
+// Policy is a client compatibility policy.
+type Policy struct {
+	// Rules are the default rules.
+	Rules
+	// Platforms holds the rules replacing the default ones for specific platforms.
+	Platforms map[string]Rules `json:"platforms,omitempty"`
+	// PreRelease is the policy for pre-release client versions.
+	PreRelease PreReleasePolicy `json:"preRelease"`
+}
+
+// Validate checks that the versions of all rules of 'p' are valid,
+// ordered as minimum <= recommended <= latest, that their target versions are not blocked,
+// and that the pre-release policy is known.
+func (p Policy) Validate() error {
+	if p.PreRelease < 0 || int(p.PreRelease) >= len(preReleaseNames) {
+		return fmt.Errorf("unknown pre-release policy %d", int(p.PreRelease))
+	}
+	if err := p.Rules.validate(); err != nil {
+		return err
+	}
+	for name, r := range p.Platforms {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("platform %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Decision is the result of [Policy.Evaluate].
+type Decision struct {
+	Verdict Verdict `json:"verdict"`
+	// Target is the version to update to: a copy of the highest of the latest, recommended and minimum versions.
+	// Target is nil if the verdict is [OK] or no such version is set.
+	Target *semver.SemVer `json:"target,omitempty"`
+	// Reason explains the verdict if it is not [OK].
+	Reason string `json:"reason,omitempty"`
+}
+
+// Evaluate evaluates 'client' against the default rules of 'p'.
+// An invalid 'client' requires an update; Evaluate returns an error
+// if the rules or the pre-release policy of 'p' are invalid (see [Policy.Validate]).
+func (p Policy) Evaluate(client semver.SemVer) (Decision, error) {
+	return p.evaluate(p.Rules, client)
+}
+
+// EvaluatePlatform evaluates 'client' against the rules of 'platform',
+// or against the default rules if 'p' has no rules for 'platform'.
+// Errors are returned as by [Policy.Evaluate].
+func (p Policy) EvaluatePlatform(platform string, client semver.SemVer) (Decision, error) {
+	r, ok := p.Platforms[platform]
+	if !ok {
+		r = p.Rules
+	}
+	d, err := p.evaluate(r, client)
+	if err != nil && ok {
+		return Decision{}, fmt.Errorf("platform %q: %w", platform, err)
+	}
+	return d, err
+}
+
+func (p Policy) evaluate(r Rules, client semver.SemVer) (Decision, error) {
+	if p.PreRelease < 0 || int(p.PreRelease) >= len(preReleaseNames) {
+		return Decision{}, fmt.Errorf("unknown pre-release policy %d", int(p.PreRelease))
+	}
+	if err := r.validate(); err != nil {
+		return Decision{}, err
+	}
+	return p.decide(r, client), nil
+}
+
+// decide evaluates 'client' against valid rules 'r'.
+func (p Policy) decide(r Rules, client semver.SemVer) Decision {
+	update := func(verdict Verdict, reason string, args ...any) Decision {
+		return Decision{Verdict: verdict, Target: r.target(), Reason: fmt.Sprintf(reason, args...)}
+	}
+	if err := semver.Valid(client); err != nil {
+		return update(UpdateRequired, "invalid version (%v)", err)
+	}
+	if r.Blocked != nil && r.Blocked.Contains(client) {
+		return update(UpdateRequired, "version %v is blocked", client)
+	}
+	v := client
+	if len(client.PreRelease) > 0 {
+		switch p.PreRelease {
+		case PreReleaseAllow:
+			return Decision{Verdict: OK}
+		case PreReleaseDeny:
+			return update(UpdateRequired, "pre-release version %v is not supported", client)
+		case PreReleaseAsRelease:
+			v.PreRelease = ""
+		}
+	}
+	if r.Minimum != nil && semver.Less(v, *r.Minimum) {
+		return update(UpdateRequired, "version %v is lower than minimum supported version %v", client, *r.Minimum)
+	}
+	if r.Recommended != nil && semver.Less(v, *r.Recommended) {
+		return update(UpdateRecommended, "version %v is lower than recommended version %v", client, *r.Recommended)
+	}
+	return Decision{Verdict: OK}
+}
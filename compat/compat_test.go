@@ -0,0 +1,231 @@
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/solsw/semver"
+)
+
+func mustParse(t *testing.T, s string) semver.SemVer {
+	t.Helper()
+	v, err := semver.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+const testPolicy = `{
+	"minimum": "2.0.0",
+	"recommended": "2.3.0",
+	"latest": "2.4.1",
+	"blocked": "2.2.5 || 2.4.0",
+	"platforms": {
+		"ios": {"minimum": "2.1.0", "latest": "2.4.2"}
+	},
+	"preRelease": "strict"
+}`
+
+func parsePolicy(t *testing.T, s string) Policy {
+	t.Helper()
+	var p Policy
+	if err := json.Unmarshal([]byte(s), &p); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// evaluate evaluates 'client' against the rules of 'platform' of valid 'p'.
+func evaluate(t *testing.T, p Policy, platform, client string) Decision {
+	t.Helper()
+	d, err := p.EvaluatePlatform(platform, mustParse(t, client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func decisionString(d Decision) string {
+	s := d.Verdict.String()
+	if d.Target != nil {
+		s += fmt.Sprintf(" -> %v", *d.Target)
+	}
+	return s
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	p := parsePolicy(t, testPolicy)
+	tests := []struct {
+		platform string
+		client   string
+		want     string
+	}{
+		{client: "1.9.9", want: "update_required -> 2.4.1"},
+		{client: "2.0.0", want: "update_recommended -> 2.4.1"},
+		{client: "2.2.5", want: "update_required -> 2.4.1"},
+		{client: "2.2.5+b", want: "update_required -> 2.4.1"},
+		{client: "2.3.0", want: "ok"},
+		{client: "2.4.0", want: "update_required -> 2.4.1"},
+		{client: "2.4.1", want: "ok"},
+		{client: "3.0.0", want: "ok"},
+		{client: "2.3.0-beta", want: "update_recommended -> 2.4.1"},
+		{client: "2.0.0-rc.1", want: "update_required -> 2.4.1"},
+		{platform: "ios", client: "2.0.0", want: "update_required -> 2.4.2"},
+		{platform: "ios", client: "2.1.0", want: "ok"},
+		{platform: "ios", client: "2.4.0", want: "ok"},
+		{platform: "android", client: "2.0.0", want: "update_recommended -> 2.4.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.platform+" "+tt.client, func(t *testing.T) {
+			d := evaluate(t, p, tt.platform, tt.client)
+			if got := decisionString(d); got != tt.want {
+				t.Errorf("EvaluatePlatform() = %v (%s), want %v", got, d.Reason, tt.want)
+			}
+			if tt.platform == "" {
+				d, err := p.Evaluate(mustParse(t, tt.client))
+				if got := decisionString(d); err != nil || got != tt.want {
+					t.Errorf("Evaluate() = %v, %v, want %v", got, err, tt.want)
+				}
+			}
+		})
+	}
+	if d, err := p.Evaluate(semver.SemVer{Major: -1}); err != nil || d.Verdict != UpdateRequired {
+		t.Errorf("Evaluate() of invalid version = %v, %v", d.Verdict, err)
+	}
+}
+
+func TestPolicy_Evaluate_InvalidPolicy(t *testing.T) {
+	client := mustParse(t, "1.0.0")
+	tests := []struct {
+		name     string
+		policy   Policy
+		platform string
+	}{
+		{name: "invalid minimum", policy: Policy{Rules: Rules{Minimum: &semver.SemVer{Major: -1}}}},
+		{name: "invalid latest", policy: Policy{Rules: Rules{Latest: &semver.SemVer{Minor: 1, PreRelease: "01"}}}},
+		{name: "invalid platform",
+			policy:   Policy{Platforms: map[string]Rules{"ios": {Recommended: &semver.SemVer{Patch: -1}}}},
+			platform: "ios",
+		},
+		{name: "unordered", policy: Policy{Rules: Rules{Minimum: &semver.SemVer{Major: 2}, Latest: &semver.SemVer{Major: 1}}}},
+		{name: "unknown pre-release policy", policy: Policy{PreRelease: PreReleasePolicy(42)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d, err := tt.policy.EvaluatePlatform(tt.platform, client); err == nil {
+				t.Errorf("EvaluatePlatform() = %v, want error", d)
+			}
+		})
+	}
+}
+
+func TestDecision_Target(t *testing.T) {
+	p := parsePolicy(t, testPolicy)
+	d := evaluate(t, p, "", "1.0.0")
+	d.Target.Major = 9
+	if *p.Latest != mustParse(t, "2.4.1") {
+		t.Errorf("changing Decision.Target changed the policy: %v", *p.Latest)
+	}
+}
+
+func TestPolicy_Evaluate_PreRelease(t *testing.T) {
+	tests := []struct {
+		policy PreReleasePolicy
+		client string
+		want   Verdict
+	}{
+		{policy: PreReleaseStrict, client: "2.0.0-beta", want: UpdateRequired},
+		{policy: PreReleaseStrict, client: "2.3.0-beta", want: UpdateRecommended},
+		{policy: PreReleaseStrict, client: "2.3.1-beta", want: OK},
+		{policy: PreReleaseAsRelease, client: "2.0.0-beta", want: UpdateRecommended},
+		{policy: PreReleaseAsRelease, client: "2.3.0-beta", want: OK},
+		{policy: PreReleaseAsRelease, client: "1.9.0-beta", want: UpdateRequired},
+		{policy: PreReleaseAllow, client: "1.0.0-beta", want: OK},
+		{policy: PreReleaseAllow, client: "2.4.0-rc.1", want: UpdateRequired},
+		{policy: PreReleaseDeny, client: "3.0.0-beta", want: UpdateRequired},
+		{policy: PreReleaseDeny, client: "3.0.0", want: OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String()+" "+tt.client, func(t *testing.T) {
+			p := parsePolicy(t, `{"minimum": "2.0.0", "recommended": "2.3.0", "blocked": "~2.4.0-0"}`)
+			p.PreRelease = tt.policy
+			if got := evaluate(t, p, "", tt.client); got.Verdict != tt.want {
+				t.Errorf("Evaluate() = %v (%s), want %v", got.Verdict, got.Reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{name: "empty", policy: `{}`},
+		{name: "minimum only", policy: `{"minimum": "1.0.0"}`},
+		{name: "unordered",
+			policy:  `{"minimum": "2.0.0", "recommended": "1.0.0"}`,
+			wantErr: "recommended version 1.0.0 is lower than minimum version 2.0.0",
+		},
+		{name: "blocked target",
+			policy:  `{"minimum": "2.0.0", "latest": "2.1.0", "blocked": ">=2.1"}`,
+			wantErr: "target version 2.1.0 is blocked",
+		},
+		{name: "platform",
+			policy:  `{"platforms": {"ios": {"recommended": "2.0.0", "latest": "1.0.0"}}}`,
+			wantErr: `platform "ios": latest version 1.0.0 is lower than recommended version 2.0.0`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Policy
+			if err := json.Unmarshal([]byte(tt.policy), &p); err != nil {
+				t.Fatal(err)
+			}
+			err := p.Validate()
+			if err != nil && err.Error() != tt.wantErr || err == nil && tt.wantErr != "" {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	p := Policy{Rules: Rules{Minimum: &semver.SemVer{Major: -1}}}
+	if err := p.Validate(); err == nil {
+		t.Errorf("Validate() of invalid version error = nil")
+	}
+	if err := json.Unmarshal([]byte(`{"preRelease": "sometimes"}`), &p); err == nil {
+		t.Errorf("Unmarshal() of unknown pre-release policy error = nil")
+	}
+}
+
+func TestDecision_JSON(t *testing.T) {
+	p := parsePolicy(t, testPolicy)
+	d := evaluate(t, p, "", "2.0.0")
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"verdict":"update_recommended","target":"2.4.1","reason":"version 2.0.0 is lower than recommended version 2.3.0"}`
+	if string(b) != want {
+		t.Errorf("Marshal() = %s, want %s", b, want)
+	}
+	var got Decision
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Verdict != d.Verdict || *got.Target != *d.Target || got.Reason != d.Reason {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, d)
+	}
+	if err := json.Unmarshal([]byte(`{"verdict":"maybe"}`), &got); err == nil {
+		t.Errorf("Unmarshal() of unknown verdict error = nil")
+	}
+	b, _ = json.Marshal(evaluate(t, p, "", "2.4.1"))
+	if got, want := string(b), `{"verdict":"ok"}`; got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,8 @@
+// Package compat decides whether a client application version is still supported.
+//
+// A [Policy] holds the minimum supported, recommended and latest versions
+// and the blocked versions of clients, optionally overridden per platform.
+// [Policy.Evaluate] returns a [Decision]: whether the client is fine,
+// is recommended to update or must update, and the version to update to.
+// Pre-release clients are handled according to an explicit [PreReleasePolicy].
+package compat
@@ -0,0 +1,120 @@
+package semver
+
+// Component is a component of a [SemVer], ordered by significance.
+type Component int
+
+const (
+	// ComponentNone means no component.
+	ComponentNone Component = iota
+	// ComponentBuild is the build metadata.
+	ComponentBuild
+	// ComponentPreRelease is the pre-release version.
+	ComponentPreRelease
+	// ComponentPatch is the patch version.
+	ComponentPatch
+	// ComponentMinor is the minor version.
+	ComponentMinor
+	// ComponentMajor is the major version.
+	ComponentMajor
+)
+
+// String implements the [fmt.Stringer] interface.
+func (c Component) String() string {
+	switch c {
+	case ComponentNone:
+		return "none"
+	case ComponentBuild:
+		return "build"
+	case ComponentPreRelease:
+		return "pre-release"
+	case ComponentPatch:
+		return "patch"
+	case ComponentMinor:
+		return "minor"
+	case ComponentMajor:
+		return "major"
+	}
+	return "unknown"
+}
+
+// Direction is the direction of a change of precedence.
+type Direction int
+
+const (
+	// DirectionNone means the same precedence.
+	DirectionNone Direction = iota
+	// DirectionUp means a higher precedence.
+	DirectionUp
+	// DirectionDown means a lower precedence.
+	DirectionDown
+)
+
+// String implements the [fmt.Stringer] interface.
+func (d Direction) String() string {
+	switch d {
+	case DirectionNone:
+		return "none"
+	case DirectionUp:
+		return "up"
+	case DirectionDown:
+		return "down"
+	}
+	return "unknown"
+}
+
+// Difference describes the change from one [SemVer] to another.
+type Difference struct {
+	// Component is the most significant changed component.
+	Component Component
+	Direction Direction
+	// Breaking reports whether the change may break compatibility.
+	Breaking bool
+}
+
+// Diff returns the [Difference] of the change from 'a' to 'b'.
+// 'a' and 'b' must be valid.
+//
+// The change is breaking if:
+//   - the major version changes (see [spec item 8]),
+//   - the precedence changes and either major version is zero,
+//     since anything may change in initial development (see [spec item 4]),
+//   - the precedence changes and either version is a pre-release version,
+//     since it might not satisfy the compatibility requirements of its normal version (see [spec item 9]),
+//   - the minor version decreases, since functionality added in the minor version is removed (see [spec item 7]).
+//
+// Changes of build metadata only are never breaking, since they do not affect precedence (see [spec item 10]).
+//
+// [spec item 4]: https://semver.org/#spec-item-4
+// [spec item 7]: https://semver.org/#spec-item-7
+// [spec item 8]: https://semver.org/#spec-item-8
+// [spec item 9]: https://semver.org/#spec-item-9
+// [spec item 10]: https://semver.org/#spec-item-10
+func Diff(a, b SemVer) Difference {
+	var d Difference
+	switch {
+	case a.Major != b.Major:
+		d.Component = ComponentMajor
+	case a.Minor != b.Minor:
+		d.Component = ComponentMinor
+	case a.Patch != b.Patch:
+		d.Component = ComponentPatch
+	case a.PreRelease != b.PreRelease:
+		d.Component = ComponentPreRelease
+	case a.Build != b.Build:
+		d.Component = ComponentBuild
+	}
+	switch r := compareValid(a, b); {
+	case r < 0:
+		d.Direction = DirectionUp
+	case r > 0:
+		d.Direction = DirectionDown
+	}
+	if d.Direction == DirectionNone {
+		return d
+	}
+	d.Breaking = d.Component == ComponentMajor ||
+		a.Major == 0 || b.Major == 0 ||
+		len(a.PreRelease) > 0 || len(b.PreRelease) > 0 ||
+		(d.Component == ComponentMinor && d.Direction == DirectionDown)
+	return d
+}
@@ -0,0 +1,71 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Difference
+	}{
+		{a: "1.2.3", b: "1.2.3", want: Difference{}},
+		{a: "1.2.3+b.1", b: "1.2.3+b.2", want: Difference{Component: ComponentBuild}},
+		{a: "1.2.3", b: "1.2.4", want: Difference{Component: ComponentPatch, Direction: DirectionUp}},
+		{a: "1.2.4", b: "1.2.3", want: Difference{Component: ComponentPatch, Direction: DirectionDown}},
+		{a: "1.2.3", b: "1.3.0", want: Difference{Component: ComponentMinor, Direction: DirectionUp}},
+		{a: "1.3.0", b: "1.2.9", want: Difference{Component: ComponentMinor, Direction: DirectionDown, Breaking: true}},
+		{a: "1.2.3", b: "2.0.0", want: Difference{Component: ComponentMajor, Direction: DirectionUp, Breaking: true}},
+		{a: "2.0.0", b: "1.9.0", want: Difference{Component: ComponentMajor, Direction: DirectionDown, Breaking: true}},
+		{a: "0.1.0", b: "0.1.1", want: Difference{Component: ComponentPatch, Direction: DirectionUp, Breaking: true}},
+		{a: "0.9.0", b: "1.0.0", want: Difference{Component: ComponentMajor, Direction: DirectionUp, Breaking: true}},
+		{a: "0.1.0+b.1", b: "0.1.0+b.2", want: Difference{Component: ComponentBuild}},
+		{a: "1.2.3-rc.1", b: "1.2.3-rc.2", want: Difference{Component: ComponentPreRelease, Direction: DirectionUp, Breaking: true}},
+		{a: "1.2.3-rc.1", b: "1.2.3", want: Difference{Component: ComponentPreRelease, Direction: DirectionUp, Breaking: true}},
+		{a: "1.2.3", b: "1.3.0-rc.1", want: Difference{Component: ComponentMinor, Direction: DirectionUp, Breaking: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := Diff(mustParse(t, tt.a), mustParse(t, tt.b)); got != tt.want {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponent_String(t *testing.T) {
+	tests := []struct {
+		c    Component
+		want string
+	}{
+		{c: ComponentNone, want: "none"},
+		{c: ComponentBuild, want: "build"},
+		{c: ComponentPreRelease, want: "pre-release"},
+		{c: ComponentPatch, want: "patch"},
+		{c: ComponentMinor, want: "minor"},
+		{c: ComponentMajor, want: "major"},
+		{c: Component(42), want: "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestDirection_String(t *testing.T) {
+	tests := []struct {
+		d    Direction
+		want string
+	}{
+		{d: DirectionNone, want: "none"},
+		{d: DirectionUp, want: "up"},
+		{d: DirectionDown, want: "down"},
+		{d: Direction(-1), want: "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
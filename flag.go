@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ParseError is returned by the [flag.Value] implementations of this package
+// when the command-line or environment value cannot be parsed.
+type ParseError struct {
+	// What is parsed: "version" or "range".
+	What string
+	// Value is the text that failed to parse.
+	Value string
+	// Err is the parse failure.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %v", e.What, e.Value, e.Err)
+}
+
+// Unwrap returns the parse failure.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Set implements the [flag.Value] interface, so *SemVer may be passed to [flag.Var].
+// Set returns a [*ParseError] if 's' is not a valid version.
+func (v *SemVer) Set(s string) error {
+	sv, err := Parse(s)
+	if err != nil {
+		return &ParseError{What: "version", Value: s, Err: err}
+	}
+	*v = sv
+	return nil
+}
+
+// Set implements the [flag.Value] interface, so *Range may be passed to [flag.Var].
+// Set returns a [*ParseError] if 's' is not a valid range.
+func (r *Range) Set(s string) error {
+	rr, err := ParseRange(s)
+	if err != nil {
+		return &ParseError{What: "range", Value: s, Err: err}
+	}
+	*r = rr
+	return nil
+}
+
+// List is a [flag.Value] collecting versions from repeated flags
+// and from comma-separated values (e.g. "-v 1.0.0 -v 1.1.0,1.2.0").
+type List []SemVer
+
+// String implements the [flag.Value] interface.
+// String returns the versions separated by commas.
+func (l *List) String() string {
+	if l == nil {
+		return ""
+	}
+	ss := make([]string, len(*l))
+	for i, v := range *l {
+		ss[i] = v.String()
+	}
+	return strings.Join(ss, ",")
+}
+
+// Set implements the [flag.Value] interface.
+// Set appends the comma-separated versions of 's' to 'l',
+// or returns a [*ParseError] for the first invalid one leaving 'l' unchanged.
+func (l *List) Set(s string) error {
+	var vv []SemVer
+	for _, part := range strings.Split(s, ",") {
+		var v SemVer
+		if err := v.Set(strings.TrimSpace(part)); err != nil {
+			return err
+		}
+		vv = append(vv, v)
+	}
+	*l = append(*l, vv...)
+	return nil
+}
+
+// LookupEnv sets 'value' from the environment variable 'key'.
+// The boolean result reports whether the variable is present.
+// The returned error names the variable and wraps the error returned by value.Set.
+func LookupEnv(key string, value flag.Value) (bool, error) {
+	s, ok := os.LookupEnv(key)
+	if !ok {
+		return false, nil
+	}
+	if err := value.Set(s); err != nil {
+		return true, fmt.Errorf("environment variable %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// EnvName returns the name of the environment variable for the flag 'name':
+// 'prefix' followed by 'name' in upper case with '-' and '.' replaced by '_'
+// (e.g. "APP_MIN_VERSION" for the prefix "APP_" and the flag "min-version").
+func EnvName(prefix, name string) string {
+	return prefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// SetFromEnv sets the flags of 'fs' (of [flag.CommandLine] if 'fs' is nil)
+// that were not set on the command line from the environment variables named by [EnvName].
+// SetFromEnv must be called after the flags are parsed.
+func SetFromEnv(fs *flag.FlagSet, prefix string) error {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || set[f.Name] {
+			return
+		}
+		_, err = LookupEnv(EnvName(prefix, f.Name), f.Value)
+	})
+	return err
+}
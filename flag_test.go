@@ -0,0 +1,138 @@
+package semver
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestSemVer_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    SemVer
+		wantErr string
+	}{
+		{name: "valid", s: "1.4.0-rc.1", want: SemVer{Major: 1, Minor: 4, PreRelease: "rc.1"}},
+		{name: "invalid", s: "1.4", wantErr: `invalid version "1.4": malformed semver`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v SemVer
+			err := v.Set(tt.s)
+			if len(tt.wantErr) > 0 {
+				var pe *ParseError
+				if !errors.As(err, &pe) || err.Error() != tt.wantErr {
+					t.Fatalf("Set() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			if v != tt.want {
+				t.Errorf("Set() = %v, want %v", v, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlags(t *testing.T) {
+	var minVersion SemVer
+	var constraint Range
+	var skip List
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&minVersion, "min-version", "")
+	fs.Var(&constraint, "constraint", "")
+	fs.Var(&skip, "skip", "")
+	err := fs.Parse([]string{"--min-version=1.4.0", "--constraint", ">=1.2 <2", "--skip", "1.5.0", "--skip=1.6.0, 1.6.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (SemVer{Major: 1, Minor: 4}); minVersion != want {
+		t.Errorf("min-version = %v, want %v", minVersion, want)
+	}
+	if got, want := constraint.String(), "^1.2.0"; got != want {
+		t.Errorf("constraint = %v, want %v", got, want)
+	}
+	if got, want := skip.String(), "1.5.0,1.6.0,1.6.1"; got != want {
+		t.Errorf("skip = %v, want %v", got, want)
+	}
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&constraint, "constraint", "")
+	err = fs.Parse([]string{"--constraint=^x.y"})
+	if want := `invalid value "^x.y" for flag -constraint: invalid range "^x.y": malformed range`; err == nil || err.Error() != want {
+		t.Errorf("Parse() error = %v, want %v", err, want)
+	}
+	var pe *ParseError
+	if err := constraint.Set("^x.y"); !errors.As(err, &pe) || pe.What != "range" || pe.Value != "^x.y" {
+		t.Errorf("Set() error = %v, want *ParseError", err)
+	}
+}
+
+func TestList_Set(t *testing.T) {
+	l := List{{Major: 1}}
+	if err := l.Set("2.0.0,bad"); err == nil {
+		t.Fatalf("Set() error = nil, want error")
+	}
+	if want := (List{{Major: 1}}); !reflect.DeepEqual(l, want) {
+		t.Errorf("Set() = %v, want %v unchanged", l, want)
+	}
+	var nilList *List
+	if got := nilList.String(); got != "" {
+		t.Errorf("String() = %q, want empty", got)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	if got, want := EnvName("APP_", "min-version"), "APP_MIN_VERSION"; got != want {
+		t.Errorf("EnvName() = %v, want %v", got, want)
+	}
+}
+
+func TestSetFromEnv(t *testing.T) {
+	t.Setenv("APP_MIN_VERSION", "1.0.0")
+	t.Setenv("APP_MAX_VERSION", "3.0.0")
+	var minVersion, maxVersion SemVer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&minVersion, "min-version", "")
+	fs.Var(&maxVersion, "max-version", "")
+	if err := fs.Parse([]string{"--max-version=2.0.0"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetFromEnv(fs, "APP_"); err != nil {
+		t.Fatal(err)
+	}
+	if want := (SemVer{Major: 1}); minVersion != want {
+		t.Errorf("min-version = %v, want %v from environment", minVersion, want)
+	}
+	if want := (SemVer{Major: 2}); maxVersion != want {
+		t.Errorf("max-version = %v, want %v from command line", maxVersion, want)
+	}
+
+	t.Setenv("APP_MIN_VERSION", "1.0")
+	err := SetFromEnv(fs, "APP_")
+	if want := `environment variable APP_MIN_VERSION: invalid version "1.0": malformed semver`; err == nil || err.Error() != want {
+		t.Errorf("SetFromEnv() error = %v, want %v", err, want)
+	}
+}
+
+func TestLookupEnv(t *testing.T) {
+	t.Setenv("APP_RANGE", "^1.2")
+	var r Range
+	ok, err := LookupEnv("APP_RANGE", &r)
+	if !ok || err != nil {
+		t.Fatalf("LookupEnv() = %v, %v", ok, err)
+	}
+	if got, want := r.String(), "^1.2.0"; got != want {
+		t.Errorf("LookupEnv() = %v, want %v", got, want)
+	}
+	if ok, err := LookupEnv("APP_UNSET_VARIABLE", &r); ok || err != nil {
+		t.Errorf("LookupEnv() = %v, %v, want false, nil", ok, err)
+	}
+}
@@ -0,0 +1,142 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements the [fmt.Formatter] interface.
+// Format supports the following verbs and flags:
+//
+//	%v   full version, as returned by [SemVer.String] ("1.2.3-rc.1+b.5")
+//	%s   version without build metadata ("1.2.3-rc.1")
+//	%#s  version without build metadata prefixed with 'v' ("v1.2.3-rc.1")
+//	%q   double-quoted full version ("\"1.2.3-rc.1+b.5\"")
+//	%+v  field-labelled struct dump ("{Major:1 Minor:2 Patch:3 PreRelease:rc.1 Build:b.5}")
+//	%#v  Go-syntax representation
+//
+// With %v, %s and %#s a precision limits the output to that many version numbers
+// and omits pre-release version and build metadata: "%.2v" gives "1.2", "%.3v" gives "1.2.3".
+// Width and the '-' flag pad the result as for strings.
+// Like [SemVer.String], Format does not validate 'v'.
+func (v SemVer) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			s = fmt.Sprintf("semver.SemVer{Major:%d, Minor:%d, Patch:%d, PreRelease:%q, Build:%q}",
+				v.Major, v.Minor, v.Patch, v.PreRelease, v.Build)
+		case f.Flag('+'):
+			s = fmt.Sprintf("{Major:%d Minor:%d Patch:%d PreRelease:%s Build:%s}",
+				v.Major, v.Minor, v.Patch, v.PreRelease, v.Build)
+		default:
+			s = v.String()
+			if prec, ok := f.Precision(); ok {
+				s = v.numbers(prec)
+			}
+		}
+	case 's':
+		s = SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch, PreRelease: v.PreRelease}.String()
+		if prec, ok := f.Precision(); ok {
+			s = v.numbers(prec)
+		}
+		if f.Flag('#') {
+			s = "v" + s
+		}
+	case 'q':
+		s = strconv.Quote(v.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(semver.SemVer=%s)", verb, v.String())
+		return
+	}
+	if width, ok := f.Width(); ok && len(s) < width {
+		pad := strings.Repeat(" ", width-len(s))
+		if f.Flag('-') {
+			s += pad
+		} else {
+			s = pad + s
+		}
+	}
+	fmt.Fprint(f, s)
+}
+
+// numbers returns the first 'n' version numbers of 'v' joined with '.'.
+func (v SemVer) numbers(n int) string {
+	nn := []int64{v.Major, v.Minor, v.Patch}
+	ss := make([]string, 0, len(nn))
+	for i := 0; i < n && i < len(nn); i++ {
+		ss = append(ss, strconv.FormatInt(nn[i], 10))
+	}
+	return strings.Join(ss, ".")
+}
+
+// FormatTemplate returns 'template' with placeholders replaced by the parts of 'v':
+//
+//	{major}  major version
+//	{minor}  minor version
+//	{patch}  patch version
+//	{pre}    pre-release version
+//	{build}  build metadata
+//	{core}   "{major}.{minor}.{patch}"
+//	{full}   the full version, as returned by [SemVer.String]
+//
+// A placeholder in the form {name?sep} expands to 'sep' followed by the part,
+// or to nothing if the part is empty; e.g. "{core}{pre?-}{build?+}" is the full version
+// and "v{major}.{minor}" is "v1.2" for "1.2.3".
+// Unknown placeholders and unmatched braces are copied verbatim.
+// Like [SemVer.String], FormatTemplate does not validate 'v'.
+func (v SemVer) FormatTemplate(template string) string {
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(template, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(template[open:], '}')
+		if end < 0 {
+			break
+		}
+		end += open
+		part, ok := v.templatePart(template[open+1 : end])
+		if !ok {
+			// copy the brace and rescan the rest, which may contain a placeholder
+			b.WriteString(template[:open+1])
+			template = template[open+1:]
+			continue
+		}
+		b.WriteString(template[:open])
+		b.WriteString(part)
+		template = template[end+1:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
+
+func (v SemVer) templatePart(placeholder string) (string, bool) {
+	name, sep, conditional := strings.Cut(placeholder, "?")
+	var part string
+	switch name {
+	case "major":
+		part = strconv.FormatInt(v.Major, 10)
+	case "minor":
+		part = strconv.FormatInt(v.Minor, 10)
+	case "patch":
+		part = strconv.FormatInt(v.Patch, 10)
+	case "pre":
+		part = v.PreRelease
+	case "build":
+		part = v.Build
+	case "core":
+		part = v.numbers(3)
+	case "full":
+		part = v.String()
+	default:
+		return "", false
+	}
+	if conditional && len(part) > 0 {
+		part = sep + part
+	}
+	return part, true
+}
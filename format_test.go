@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSemVer_Format(t *testing.T) {
+	v := parseMust("1.2.3-rc.1+b.5")
+	tests := []struct {
+		name   string
+		format string
+		v      SemVer
+		want   string
+	}{
+		{name: "1", format: "%v", v: v, want: "1.2.3-rc.1+b.5"},
+		{name: "2", format: "%s", v: v, want: "1.2.3-rc.1"},
+		{name: "3", format: "%#s", v: v, want: "v1.2.3-rc.1"},
+		{name: "4", format: "%q", v: v, want: `"1.2.3-rc.1+b.5"`},
+		{name: "5", format: "%+v", v: v, want: "{Major:1 Minor:2 Patch:3 PreRelease:rc.1 Build:b.5}"},
+		{name: "6", format: "%#v", v: v, want: `semver.SemVer{Major:1, Minor:2, Patch:3, PreRelease:"rc.1", Build:"b.5"}`},
+		{name: "7", format: "%.1v", v: v, want: "1"},
+		{name: "8", format: "%.2v", v: v, want: "1.2"},
+		{name: "9", format: "%.3s", v: v, want: "1.2.3"},
+		{name: "10", format: "%#.2s", v: v, want: "v1.2"},
+		{name: "11", format: "%.9v", v: v, want: "1.2.3"},
+		{name: "12", format: "%.0v", v: v, want: ""},
+		{name: "13", format: "[%8v]", v: parseMust("1.2.3"), want: "[   1.2.3]"},
+		{name: "14", format: "[%-8s]", v: parseMust("1.2.3+b"), want: "[1.2.3   ]"},
+		{name: "15", format: "[%2v]", v: parseMust("1.2.3"), want: "[1.2.3]"},
+		{name: "16", format: "%d", v: v, want: "%!d(semver.SemVer=1.2.3-rc.1+b.5)"},
+		{name: "17", format: "%v", v: SemVer{}, want: "0.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.v); got != tt.want {
+				t.Errorf("fmt.Sprintf(%q) = %v, want %v", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVer_FormatTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        SemVer
+		template string
+		want     string
+	}{
+		{name: "1", v: parseMust("1.2.3-rc.1+b.5"), template: "", want: ""},
+		{name: "2", v: parseMust("1.2.3-rc.1+b.5"), template: "{major}.{minor}", want: "1.2"},
+		{name: "3", v: parseMust("1.2.3-rc.1+b.5"), template: "v{core}{pre?-}", want: "v1.2.3-rc.1"},
+		{name: "4", v: parseMust("1.2.3"), template: "v{core}{pre?-}", want: "v1.2.3"},
+		{name: "5", v: parseMust("1.2.3-rc.1+b.5"), template: "{core}{pre?-}{build?+}", want: "1.2.3-rc.1+b.5"},
+		{name: "6", v: parseMust("1.2.3+b.5"), template: "{major}.{minor}.{patch}{pre?-}{build?+}", want: "1.2.3+b.5"},
+		{name: "7", v: parseMust("1.2.3+b.5"), template: "{full} ({build})", want: "1.2.3+b.5 (b.5)"},
+		{name: "8", v: parseMust("1.2.3-rc.1"), template: "{pre} {build}.", want: "rc.1 ."},
+		{name: "9", v: parseMust("1.2.3"), template: "{unknown}-{major", want: "{unknown}-{major"},
+		{name: "10", v: parseMust("1.2.3"), template: "}{{major}}", want: "}{1}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.FormatTemplate(tt.template); got != tt.want {
+				t.Errorf("SemVer.FormatTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package semver
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// officialRegexp is the regular expression suggested by semver.org,
+// see https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string.
+var officialRegexp = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// readCorpus returns the lines of a testdata file except empty lines and '#' comments.
+func readCorpus(t testing.TB, name string) []string {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var ss []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if s := sc.Text(); len(s) > 0 && !strings.HasPrefix(s, "#") {
+			ss = append(ss, s)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return ss
+}
+
+// checkOracle checks that Parse and ParseBig agree with the official regular expression.
+// The only expected divergence is Parse rejecting version numbers that overflow int64.
+func checkOracle(t *testing.T, s string) {
+	t.Helper()
+	want := officialRegexp.MatchString(s)
+	if _, err := ParseBig(s); (err == nil) != want {
+		t.Errorf("ParseBig(%q) error = %v, regexp match = %v", s, err, want)
+	}
+	sv, err := Parse(s)
+	if err == nil {
+		if !want {
+			t.Errorf("Parse(%q) accepted, regexp does not match", s)
+		}
+		if got := sv.String(); got != s {
+			t.Errorf("Parse(%q).String() = %q", s, got)
+		}
+		return
+	}
+	if want {
+		bv, _ := ParseBig(s)
+		if _, err := bv.SemVer(); err == nil {
+			t.Errorf("Parse(%q) error = %v, regexp matches and version numbers fit int64", s, err)
+		}
+	}
+}
+
+func TestConformance(t *testing.T) {
+	for _, s := range readCorpus(t, "valid.txt") {
+		if !officialRegexp.MatchString(s) {
+			t.Errorf("valid.txt: regexp does not match %q", s)
+		}
+		checkOracle(t, s)
+	}
+	for _, s := range readCorpus(t, "invalid.txt") {
+		if officialRegexp.MatchString(s) {
+			t.Errorf("invalid.txt: regexp matches %q", s)
+		}
+		checkOracle(t, s)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, name := range []string{"valid.txt", "invalid.txt"} {
+		for _, s := range readCorpus(f, name) {
+			f.Add(s)
+		}
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		checkOracle(t, s)
+		checkPattern(t, s)
+		sv, err := Parse(s)
+		if err != nil {
+			return
+		}
+		if err := Valid(sv); err != nil {
+			t.Errorf("Valid(Parse(%q)) error = %v", s, err)
+		}
+		if r, err := Compare(sv, sv); r != 0 || err != nil {
+			t.Errorf("Compare(%q, %q) = %d, %v", s, s, r, err)
+		}
+	})
+}
+
+func FuzzCompare(f *testing.F) {
+	f.Add("1.0.0-alpha", "1.0.0-alpha.1", "1.0.0")
+	f.Add("1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11")
+	f.Add("1.0.0-rc.1", "1.0.0-rc.1+b", "1.0.0-1")
+	f.Add("2.1.1", "2.1.0", "2.0.0")
+	f.Add("1.0.0-a.b-c", "1.0.0-a.b", "1.0.0-a.b.c")
+	f.Fuzz(func(t *testing.T, s1, s2, s3 string) {
+		a, err1 := Parse(s1)
+		b, err2 := Parse(s2)
+		c, err3 := Parse(s3)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return
+		}
+		ab, _ := Compare(a, b)
+		ba, _ := Compare(b, a)
+		if ab != -ba {
+			t.Errorf("Compare(%s, %s) = %d, Compare(%s, %s) = %d", s1, s2, ab, s2, s1, ba)
+		}
+		bc, _ := Compare(b, c)
+		ac, _ := Compare(a, c)
+		if ab <= 0 && bc <= 0 && ac > 0 {
+			t.Errorf("%s <= %s <= %s, but Compare(%s, %s) = %d", s1, s2, s3, s1, s3, ac)
+		}
+		if ab >= 0 && bc >= 0 && ac < 0 {
+			t.Errorf("%s >= %s >= %s, but Compare(%s, %s) = %d", s1, s2, s3, s1, s3, ac)
+		}
+		if eq := a.Major == b.Major && a.Minor == b.Minor && a.Patch == b.Patch && a.PreRelease == b.PreRelease; eq != (ab == 0) {
+			t.Errorf("Compare(%s, %s) = %d, equal precedence %v", s1, s2, ab, eq)
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package semver
+
+import (
+	"fmt"
+	"time"
+)
+
+func versionCore(v SemVer) SemVer {
+	return SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// IsSuccessor reports whether valid 'next' directly follows valid 'prev':
+//   - 'next' has the same version core (major.minor.patch) as pre-release 'prev' and a higher precedence
+//     (a later pre-release or the release), or
+//   - 'prev' is a release and 'next' (possibly a pre-release) increments its [patch],
+//     its [minor] resetting patch, or its [major] resetting minor and patch.
+//
+// [patch]: https://semver.org/#spec-item-6
+// [minor]: https://semver.org/#spec-item-7
+// [major]: https://semver.org/#spec-item-8
+func IsSuccessor(prev, next SemVer) bool {
+	pc, nc := versionCore(prev), versionCore(next)
+	if len(prev.PreRelease) > 0 {
+		return pc == nc && compareValid(prev, next) < 0
+	}
+	return nc == SemVer{Major: pc.Major, Minor: pc.Minor, Patch: pc.Patch + 1} ||
+		nc == SemVer{Major: pc.Major, Minor: pc.Minor + 1} ||
+		nc == SemVer{Major: pc.Major + 1}
+}
+
+// AnomalyKind is the kind of an [Anomaly].
+type AnomalyKind int
+
+const (
+	// AnomalyInvalid marks an invalid version.
+	AnomalyInvalid AnomalyKind = iota
+	// AnomalyDuplicate marks a version of the same precedence as an earlier published one.
+	AnomalyDuplicate
+	// AnomalyNoPredecessor marks a version that is not a successor (see [IsSuccessor])
+	// of any lower version of the history. The lowest version is exempt.
+	AnomalyNoPredecessor
+	// AnomalyGap marks a version without predecessor whose patch version skips
+	// patch versions after the highest lower release of the same major.minor line.
+	AnomalyGap
+	// AnomalyPreReleaseAfterFinal marks a pre-release version published after its release.
+	AnomalyPreReleaseAfterFinal
+	// AnomalyOutOfOrder marks a version published after a higher version of the same major.minor line.
+	// Versions published after higher versions of other lines (backports) are not anomalies.
+	AnomalyOutOfOrder
+)
+
+// String implements the [fmt.Stringer] interface.
+func (k AnomalyKind) String() string {
+	switch k {
+	case AnomalyInvalid:
+		return "invalid"
+	case AnomalyDuplicate:
+		return "duplicate"
+	case AnomalyNoPredecessor:
+		return "no predecessor"
+	case AnomalyGap:
+		return "gap"
+	case AnomalyPreReleaseAfterFinal:
+		return "pre-release after final"
+	case AnomalyOutOfOrder:
+		return "out of order"
+	}
+	return "unknown"
+}
+
+// Anomaly is a finding of [AnalyzeHistory].
+type Anomaly struct {
+	Kind    AnomalyKind
+	Version SemVer
+	// Other is the other version involved, if any:
+	// the earlier duplicate (AnomalyDuplicate),
+	// the highest lower release of the line (AnomalyGap),
+	// the release (AnomalyPreReleaseAfterFinal) or
+	// the higher version published earlier (AnomalyOutOfOrder).
+	Other SemVer
+}
+
+// String implements the [fmt.Stringer] interface.
+func (a Anomaly) String() string {
+	switch a.Kind {
+	case AnomalyDuplicate:
+		return fmt.Sprintf("%v: duplicate of %v", a.Version, a.Other)
+	case AnomalyGap:
+		return fmt.Sprintf("%v: patch versions skipped after %v", a.Version, a.Other)
+	case AnomalyPreReleaseAfterFinal:
+		return fmt.Sprintf("%v: published after %v", a.Version, a.Other)
+	case AnomalyOutOfOrder:
+		return fmt.Sprintf("%v: published after higher %v", a.Version, a.Other)
+	}
+	return fmt.Sprintf("%v: %s", a.Version, a.Kind)
+}
+
+// Release is a published version.
+type Release struct {
+	Version SemVer
+	// Published is the publish time; the zero value means unknown.
+	Published time.Time
+}
+
+// AnalyzeHistory reports the anomalies of a release history in the order of 'history'.
+// Releases are considered published in the order of their Published times
+// or, if either time is unknown, in the order of 'history'.
+func AnalyzeHistory(history []Release) []Anomaly {
+	// before reports whether the i-th release was published before the j-th one
+	before := func(i, j int) bool {
+		ti, tj := history[i].Published, history[j].Published
+		if ti.IsZero() || tj.IsZero() || ti.Equal(tj) {
+			return i < j
+		}
+		return ti.Before(tj)
+	}
+	var valid []int
+	var aa []Anomaly
+	for i, r := range history {
+		if r.Version.IsValid() {
+			valid = append(valid, i)
+		}
+	}
+	lowest := -1
+	for _, i := range valid {
+		if lowest < 0 || compareValid(history[i].Version, history[lowest].Version) < 0 {
+			lowest = i
+		}
+	}
+	for i, r := range history {
+		v := r.Version
+		if !v.IsValid() {
+			aa = append(aa, Anomaly{Kind: AnomalyInvalid, Version: v})
+			continue
+		}
+		duplicate := false
+		for _, j := range valid {
+			if j != i && before(j, i) && compareValid(history[j].Version, v) == 0 {
+				aa = append(aa, Anomaly{Kind: AnomalyDuplicate, Version: v, Other: history[j].Version})
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		if compareValid(v, history[lowest].Version) > 0 {
+			if a, ok := predecessorAnomaly(history, valid, v); ok {
+				aa = append(aa, a)
+			}
+		}
+		for _, j := range valid {
+			w := history[j].Version
+			if !before(j, i) || v.Major != w.Major || v.Minor != w.Minor || compareValid(w, v) <= 0 {
+				continue
+			}
+			if len(v.PreRelease) > 0 && len(w.PreRelease) == 0 && versionCore(v) == versionCore(w) {
+				aa = append(aa, Anomaly{Kind: AnomalyPreReleaseAfterFinal, Version: v, Other: w})
+			} else {
+				aa = append(aa, Anomaly{Kind: AnomalyOutOfOrder, Version: v, Other: w})
+			}
+			break
+		}
+	}
+	return aa
+}
+
+// predecessorAnomaly returns the AnomalyNoPredecessor or AnomalyGap anomaly of 'v'
+// if no lower version of 'history' is its predecessor.
+func predecessorAnomaly(history []Release, valid []int, v SemVer) (Anomaly, bool) {
+	var gapAfter *SemVer
+	for _, j := range valid {
+		w := history[j].Version
+		if compareValid(w, v) >= 0 {
+			continue
+		}
+		if IsSuccessor(w, v) {
+			return Anomaly{}, false
+		}
+		if len(w.PreRelease) == 0 && w.Major == v.Major && w.Minor == v.Minor &&
+			(gapAfter == nil || compareValid(*gapAfter, w) < 0) {
+			gapAfter = &history[j].Version
+		}
+	}
+	if gapAfter != nil {
+		return Anomaly{Kind: AnomalyGap, Version: v, Other: *gapAfter}, true
+	}
+	return Anomaly{Kind: AnomalyNoPredecessor, Version: v}, true
+}
+
+// AnalyzeVersions is like [AnalyzeHistory] for versions published in the order of 'vv'.
+func AnalyzeVersions(vv ...SemVer) []Anomaly {
+	history := make([]Release, len(vv))
+	for i, v := range vv {
+		history[i].Version = v
+	}
+	return AnalyzeHistory(history)
+}
@@ -0,0 +1,119 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsSuccessor(t *testing.T) {
+	tests := []struct {
+		prev, next string
+		want       bool
+	}{
+		{prev: "1.2.3", next: "1.2.4", want: true},
+		{prev: "1.2.3", next: "1.3.0", want: true},
+		{prev: "1.2.3", next: "2.0.0", want: true},
+		{prev: "1.2.3", next: "2.0.0-rc.1", want: true},
+		{prev: "1.2.3", next: "1.2.4+b.1", want: true},
+		{prev: "1.2.3-rc.1", next: "1.2.3-rc.2", want: true},
+		{prev: "1.2.3-rc.1", next: "1.2.3", want: true},
+		{prev: "1.2.3", next: "1.2.5"},
+		{prev: "1.2.3", next: "1.3.1"},
+		{prev: "1.2.3", next: "2.1.0"},
+		{prev: "1.2.3", next: "1.2.3"},
+		{prev: "1.2.3", next: "1.2.2"},
+		{prev: "1.2.3-rc.2", next: "1.2.3-rc.1"},
+		{prev: "1.2.3-rc.1", next: "1.2.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prev+" "+tt.next, func(t *testing.T) {
+			if got := IsSuccessor(mustParse(t, tt.prev), mustParse(t, tt.next)); got != tt.want {
+				t.Errorf("IsSuccessor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, s string) SemVer {
+	t.Helper()
+	v, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestAnalyzeVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		vv   []string
+		want []string
+	}{
+		{name: "empty"},
+		{name: "well-formed",
+			vv: []string{"1.0.0", "1.0.1", "1.1.0-rc.1", "1.1.0-rc.2", "1.1.0", "2.0.0", "1.1.1"},
+		},
+		{name: "patch gap",
+			vv:   []string{"1.2.3", "1.2.5", "1.2.6"},
+			want: []string{"1.2.5: patch versions skipped after 1.2.3"},
+		},
+		{name: "no predecessor",
+			vv:   []string{"1.0.0", "1.2.0", "3.0.0"},
+			want: []string{"1.2.0: no predecessor", "3.0.0: no predecessor"},
+		},
+		{name: "pre-release after final",
+			vv:   []string{"1.0.0-rc.1", "1.0.0", "1.0.0-rc.2"},
+			want: []string{"1.0.0-rc.2: published after 1.0.0"},
+		},
+		{name: "out of order",
+			vv:   []string{"1.0.0", "1.0.2", "1.0.1", "1.0.3"},
+			want: []string{"1.0.1: published after higher 1.0.2"},
+		},
+		{name: "out of order pre-releases",
+			vv:   []string{"1.0.0-rc.2", "1.0.0-rc.1"},
+			want: []string{"1.0.0-rc.1: published after higher 1.0.0-rc.2"},
+		},
+		{name: "backport",
+			vv: []string{"1.0.0", "1.1.0", "2.0.0", "1.1.1", "1.0.1"},
+		},
+		{name: "duplicate",
+			vv:   []string{"1.0.0", "1.0.1+b.1", "1.0.1+b.2"},
+			want: []string{"1.0.1+b.2: duplicate of 1.0.1+b.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var vv []SemVer
+			for _, s := range tt.vv {
+				vv = append(vv, mustParse(t, s))
+			}
+			var got []string
+			for _, a := range AnalyzeVersions(vv...) {
+				got = append(got, a.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AnalyzeVersions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	history := []Release{
+		{Version: SemVer{Major: 1, Minor: 0, Patch: 1}, Published: day(3)},
+		{Version: SemVer{Major: 1}, Published: day(1)},
+		{Version: SemVer{Major: 1, Minor: 1, PreRelease: "rc.1"}, Published: day(5)},
+		{Version: SemVer{Major: 1, Minor: 1}, Published: day(4)},
+		{Version: SemVer{Major: -1}},
+		{Version: SemVer{Major: 1, Patch: 2}},
+	}
+	want := []Anomaly{
+		{Kind: AnomalyPreReleaseAfterFinal, Version: SemVer{Major: 1, Minor: 1, PreRelease: "rc.1"}, Other: SemVer{Major: 1, Minor: 1}},
+		{Kind: AnomalyInvalid, Version: SemVer{Major: -1}},
+	}
+	if got := AnalyzeHistory(history); !reflect.DeepEqual(got, want) {
+		t.Errorf("AnalyzeHistory() = %v, want %v", got, want)
+	}
+}
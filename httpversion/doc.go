@@ -0,0 +1,9 @@
+// Package httpversion implements HTTP API version negotiation over [semver.SemVer] versions.
+//
+// A [Router] dispatches each request to the handler registered for the best version
+// the request accepts: an exact version in the X-API-Version header
+// or a [semver.Range] in the Accept-Version header.
+// The served version is reported in the X-API-Version response header
+// and is available to handlers through [FromContext].
+// Requests that cannot be served get a JSON-encoded [*Error].
+package httpversion
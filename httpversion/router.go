@@ -0,0 +1,213 @@
+package httpversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/solsw/semver"
+)
+
+const (
+	// HeaderAcceptVersion is the request header holding the accepted version range, e.g. "^2.1".
+	HeaderAcceptVersion = "Accept-Version"
+	// HeaderAPIVersion is the request header holding the exact requested version, e.g. "2.3.0",
+	// and the response header holding the served version.
+	HeaderAPIVersion = "X-API-Version"
+)
+
+// PreReleasePolicy determines when handlers registered for pre-release versions
+// are selected for a version range.
+// Handlers are always selected for an exact version, pre-release or not.
+type PreReleasePolicy int
+
+const (
+	// PreReleaseExact selects pre-release versions only for requests naming them exactly.
+	PreReleaseExact PreReleasePolicy = iota
+	// PreReleaseFallback selects pre-release versions only if no release version matches.
+	PreReleaseFallback
+	// PreReleaseAllow selects pre-release versions like release versions.
+	PreReleaseAllow
+)
+
+// Error codes of [Error].
+const (
+	CodeInvalidVersion  = "invalid_version"
+	CodeInvalidRange    = "invalid_range"
+	CodeVersionRequired = "version_required"
+	CodeNotAcceptable   = "version_not_acceptable"
+)
+
+// Error describes a request that cannot be served.
+// The router writes it as the JSON response body.
+type Error struct {
+	// Status is the HTTP status code: 400 for malformed or missing versions, 406 if no version matches.
+	Status int `json:"status"`
+	// Code is one of the Code constants.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Requested is the requested version or range.
+	Requested string `json:"requested,omitempty"`
+	// Available lists the registered versions in ascending order if no version matches.
+	Available []string `json:"available,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type contextKey struct{}
+
+// FromContext returns the version served for the request with context 'ctx'.
+// The boolean result reports whether the context holds the version.
+func FromContext(ctx context.Context) (semver.SemVer, bool) {
+	v, ok := ctx.Value(contextKey{}).(semver.SemVer)
+	return v, ok
+}
+
+type entry struct {
+	v semver.SemVer
+	h http.Handler
+}
+
+// Router is an [http.Handler] dispatching requests to handlers registered for versions.
+// A request without version headers is served by the highest version (see PreRelease),
+// unless RequireVersion is set.
+//
+// Router's zero value is a router without handlers ready to use.
+// Handlers must be registered before the router serves requests.
+type Router struct {
+	// PreRelease is the policy for pre-release versions.
+	PreRelease PreReleasePolicy
+	// RequireVersion makes requests without version headers fail with status 400.
+	RequireVersion bool
+	// Parser parses the version headers; nil means the parser returned by [semver.NetworkParser].
+	Parser *semver.Parser
+	// entries are sorted by version in ascending order
+	entries []entry
+}
+
+// Handle registers 'h' for version 'v'.
+// Handle panics if 'v' is invalid or a handler for 'v' is already registered.
+func (rt *Router) Handle(v semver.SemVer, h http.Handler) {
+	if err := semver.Valid(v); err != nil {
+		panic(fmt.Sprintf("httpversion: %v", err))
+	}
+	i, found := slices.BinarySearchFunc(rt.entries, v, func(e entry, v semver.SemVer) int {
+		r, _ := semver.Compare(e.v, v)
+		return r
+	})
+	if found {
+		panic(fmt.Sprintf("httpversion: multiple registrations for %s", v))
+	}
+	rt.entries = slices.Insert(rt.entries, i, entry{v: v, h: h})
+}
+
+// HandleFunc registers the handler function 'f' for version 'v'.
+func (rt *Router) HandleFunc(v semver.SemVer, f func(http.ResponseWriter, *http.Request)) {
+	rt.Handle(v, http.HandlerFunc(f))
+}
+
+// Versions returns the registered versions in ascending order.
+func (rt *Router) Versions() []semver.SemVer {
+	vv := make([]semver.SemVer, len(rt.entries))
+	for i, e := range rt.entries {
+		vv[i] = e.v
+	}
+	return vv
+}
+
+func (rt *Router) available() []string {
+	ss := make([]string, len(rt.entries))
+	for i, e := range rt.entries {
+		ss[i] = e.v.String()
+	}
+	return ss
+}
+
+// Select returns the version and the handler for 'req'.
+// The exact version in the X-API-Version header takes precedence over the Accept-Version range.
+// Select returns an [*Error] if the request cannot be served.
+func (rt *Router) Select(req *http.Request) (semver.SemVer, http.Handler, error) {
+	p := semver.NetworkParser()
+	if rt.Parser != nil {
+		p = *rt.Parser
+	}
+	if s := strings.TrimSpace(req.Header.Get(HeaderAPIVersion)); len(s) > 0 {
+		v, err := p.Parse(s)
+		if err != nil {
+			return semver.SemVer{}, nil, &Error{Status: http.StatusBadRequest, Code: CodeInvalidVersion,
+				Message: fmt.Sprintf("invalid %s header: %v", HeaderAPIVersion, err), Requested: s}
+		}
+		for _, e := range rt.entries {
+			if r, _ := semver.Compare(e.v, v); r == 0 {
+				return e.v, e.h, nil
+			}
+		}
+		return semver.SemVer{}, nil, rt.notAcceptable(s)
+	}
+	r := semver.AnyRange()
+	s := strings.TrimSpace(req.Header.Get(HeaderAcceptVersion))
+	if len(s) > 0 {
+		var err error
+		if r, err = p.ParseRange(s); err != nil {
+			return semver.SemVer{}, nil, &Error{Status: http.StatusBadRequest, Code: CodeInvalidRange,
+				Message: fmt.Sprintf("invalid %s header: %v", HeaderAcceptVersion, err), Requested: s}
+		}
+	} else if rt.RequireVersion {
+		return semver.SemVer{}, nil, &Error{Status: http.StatusBadRequest, Code: CodeVersionRequired,
+			Message: fmt.Sprintf("%s or %s header is required", HeaderAPIVersion, HeaderAcceptVersion)}
+	}
+	var pre *entry
+	for i := len(rt.entries) - 1; i >= 0; i-- {
+		e := &rt.entries[i]
+		if !r.Contains(e.v) {
+			continue
+		}
+		if len(e.v.PreRelease) == 0 || rt.PreRelease == PreReleaseAllow {
+			return e.v, e.h, nil
+		}
+		if pre == nil && rt.PreRelease == PreReleaseFallback {
+			pre = e
+		}
+	}
+	if pre != nil {
+		return pre.v, pre.h, nil
+	}
+	return semver.SemVer{}, nil, rt.notAcceptable(s)
+}
+
+func (rt *Router) notAcceptable(requested string) *Error {
+	msg := "no registered version is acceptable"
+	if len(requested) > 0 {
+		msg = fmt.Sprintf("no registered version matches %q", requested)
+	}
+	return &Error{Status: http.StatusNotAcceptable, Code: CodeNotAcceptable,
+		Message: msg, Requested: requested, Available: rt.available()}
+}
+
+// ServeHTTP implements the [http.Handler] interface.
+// ServeHTTP sets the X-API-Version response header to the served version
+// and stores the version in the request context (see [FromContext]).
+func (rt *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Add("Vary", HeaderAPIVersion+", "+HeaderAcceptVersion)
+	v, h, err := rt.Select(req)
+	if err != nil {
+		WriteError(w, err.(*Error))
+		return
+	}
+	w.Header().Set(HeaderAPIVersion, v.String())
+	h.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), contextKey{}, v)))
+}
+
+// WriteError writes 'e' as a JSON response with status e.Status.
+func WriteError(w http.ResponseWriter, e *Error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(e.Status)
+	json.NewEncoder(w).Encode(e)
+}
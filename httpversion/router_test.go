@@ -0,0 +1,228 @@
+package httpversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/solsw/semver"
+)
+
+func newRouter(t *testing.T, policy PreReleasePolicy, versions ...string) *Router {
+	t.Helper()
+	rt := &Router{PreRelease: policy}
+	for _, s := range versions {
+		v, err := semver.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rt.HandleFunc(v, func(w http.ResponseWriter, req *http.Request) {
+			served, _ := FromContext(req.Context())
+			fmt.Fprintf(w, "handler %s served %s", s, served)
+		})
+	}
+	return rt
+}
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	versions := []string{"1.0.0", "2.0.0", "2.1.0", "2.3.0", "2.4.0-beta.1", "3.0.0-rc.1"}
+	tests := []struct {
+		name       string
+		policy     PreReleasePolicy
+		header     string
+		value      string
+		wantStatus int
+		wantServed string
+	}{
+		{name: "no headers",
+			wantStatus: http.StatusOK,
+			wantServed: "2.3.0",
+		},
+		{name: "no headers fallback", policy: PreReleaseFallback,
+			wantStatus: http.StatusOK,
+			wantServed: "2.3.0",
+		},
+		{name: "no headers allow", policy: PreReleaseAllow,
+			wantStatus: http.StatusOK,
+			wantServed: "3.0.0-rc.1",
+		},
+		{name: "caret range", header: HeaderAcceptVersion, value: "^2.1",
+			wantStatus: http.StatusOK,
+			wantServed: "2.3.0",
+		},
+		{name: "caret range allow", policy: PreReleaseAllow, header: HeaderAcceptVersion, value: "^2.1",
+			wantStatus: http.StatusOK,
+			wantServed: "2.4.0-beta.1",
+		},
+		{name: "tilde range", header: HeaderAcceptVersion, value: "~2.1",
+			wantStatus: http.StatusOK,
+			wantServed: "2.1.0",
+		},
+		{name: "pre-release only exact", header: HeaderAcceptVersion, value: "^3.0.0-0",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{name: "pre-release fallback", policy: PreReleaseFallback, header: HeaderAcceptVersion, value: "^3.0.0-0",
+			wantStatus: http.StatusOK,
+			wantServed: "3.0.0-rc.1",
+		},
+		{name: "exact version", header: HeaderAPIVersion, value: "2.0.0",
+			wantStatus: http.StatusOK,
+			wantServed: "2.0.0",
+		},
+		{name: "exact version ignores build", header: HeaderAPIVersion, value: "2.0.0+b.1",
+			wantStatus: http.StatusOK,
+			wantServed: "2.0.0",
+		},
+		{name: "exact pre-release", header: HeaderAPIVersion, value: "3.0.0-rc.1",
+			wantStatus: http.StatusOK,
+			wantServed: "3.0.0-rc.1",
+		},
+		{name: "exact not registered", header: HeaderAPIVersion, value: "2.2.0",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{name: "range not matching", header: HeaderAcceptVersion, value: ">=4",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{name: "invalid version", header: HeaderAPIVersion, value: "2.3",
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "invalid range", header: HeaderAcceptVersion, value: "^two",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := newRouter(t, tt.policy, versions...)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if len(tt.header) > 0 {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			rt.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(HeaderAPIVersion); got != tt.wantServed {
+				t.Errorf("ServeHTTP() %s = %q, want %q", HeaderAPIVersion, got, tt.wantServed)
+			}
+			if len(tt.wantServed) > 0 {
+				want := fmt.Sprintf("handler %s served %s", tt.wantServed, tt.wantServed)
+				if got := rec.Body.String(); got != want {
+					t.Errorf("ServeHTTP() body = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRouter_ServeHTTP_Error(t *testing.T) {
+	rt := newRouter(t, PreReleaseExact, "1.0.0", "2.0.0")
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderAcceptVersion, "^3")
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	var got Error
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := Error{
+		Status:    http.StatusNotAcceptable,
+		Code:      CodeNotAcceptable,
+		Message:   `no registered version matches "^3"`,
+		Requested: "^3",
+		Available: []string{"1.0.0", "2.0.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("body = %+v, want %+v", got, want)
+	}
+}
+
+func TestRouter_RequireVersion(t *testing.T) {
+	rt := newRouter(t, PreReleaseExact, "1.0.0")
+	rt.RequireVersion = true
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	var got Error
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Code != CodeVersionRequired {
+		t.Errorf("code = %q, want %q", got.Code, CodeVersionRequired)
+	}
+}
+
+func TestRouter_Handle(t *testing.T) {
+	tests := []struct {
+		name string
+		v    semver.SemVer
+	}{
+		{name: "invalid", v: semver.SemVer{Major: -1}},
+		{name: "duplicate", v: semver.SemVer{Major: 1, Build: "b.1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := newRouter(t, PreReleaseExact, "1.0.0")
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Handle() did not panic")
+				}
+			}()
+			rt.Handle(tt.v, http.NotFoundHandler())
+		})
+	}
+	rt := newRouter(t, PreReleaseExact, "2.0.0", "1.0.0", "1.5.0")
+	if got, want := rt.Versions(), []semver.SemVer{{Major: 1}, {Major: 1, Minor: 5}, {Major: 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Versions() = %v, want %v", got, want)
+	}
+}
+
+func TestRouter_Parser(t *testing.T) {
+	long := "1.0.0-" + strings.Repeat("a", 300)
+	tests := []struct {
+		name       string
+		parser     *semver.Parser
+		wantStatus int
+	}{
+		{name: "network parser", wantStatus: http.StatusBadRequest},
+		{name: "no limits", parser: &semver.Parser{}, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := newRouter(t, PreReleaseExact, "1.0.0", long)
+			rt.Parser = tt.parser
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(HeaderAPIVersion, long)
+			rec := httptest.NewRecorder()
+			rt.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRouter_Parser_Range(t *testing.T) {
+	rt := newRouter(t, PreReleaseAllow, "1.0.0")
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	// shorter than the length limit, but with an identifier exceeding the identifier length limit
+	req.Header.Set(HeaderAcceptVersion, ">=1.0.0-"+strings.Repeat("a", 100))
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, req)
+	var got Error
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != http.StatusBadRequest || got.Code != CodeInvalidRange {
+		t.Errorf("status = %d, code = %q, want %d, %q", got.Status, got.Code, http.StatusBadRequest, CodeInvalidRange)
+	}
+}
@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Identifier is a single dot-separated identifier of a [pre-release version] or [build metadata].
+//
+// [pre-release version]: https://semver.org/#spec-item-9
+// [build metadata]: https://semver.org/#spec-item-10
+type Identifier string
+
+// IsNumeric reports whether 'id' is a numeric identifier, i.e. consists of ASCII digits only.
+func (id Identifier) IsNumeric() bool {
+	return isNumeric(string(id))
+}
+
+// Int64 returns the value of numeric identifier 'id'.
+// Int64 returns an error if 'id' is not numeric or does not fit into int64.
+func (id Identifier) Int64() (int64, error) {
+	if !id.IsNumeric() {
+		return 0, errors.New("identifier is not numeric")
+	}
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("identifier is out of range (%w)", err)
+	}
+	return n, nil
+}
+
+func splitIdentifiers(ext string) []Identifier {
+	if len(ext) == 0 {
+		return nil
+	}
+	ss := strings.Split(ext, ".")
+	ids := make([]Identifier, len(ss))
+	for i, s := range ss {
+		ids[i] = Identifier(s)
+	}
+	return ids
+}
+
+func joinIdentifiers(ids []Identifier) string {
+	var b strings.Builder
+	for i, id := range ids {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(string(id))
+	}
+	return b.String()
+}
+
+// appendIdentifiers validates 'add' with the rules of validIdent and returns
+// a new slice consisting of 'ids' followed by 'add'; 'ids' is never modified.
+func appendIdentifiers(ids []Identifier, preRelease bool, add []string) ([]Identifier, error) {
+	r := make([]Identifier, len(ids), len(ids)+len(add))
+	copy(r, ids)
+	for _, s := range add {
+		if err := validIdent(s, preRelease); err != nil {
+			return nil, err
+		}
+		r = append(r, Identifier(s))
+	}
+	return r, nil
+}
+
+// PreReleaseVersion is a structured view of the identifiers of a [pre-release version].
+// PreReleaseVersion's zero value is an empty pre-release version.
+//
+// [pre-release version]: https://semver.org/#spec-item-9
+type PreReleaseVersion struct {
+	ids []Identifier
+}
+
+// ParsePreReleaseVersion converts the pre-release version string
+// (without the leading '-') to a [PreReleaseVersion].
+func ParsePreReleaseVersion(s string) (PreReleaseVersion, error) {
+	if err := validExt(s, true); err != nil {
+		return PreReleaseVersion{}, err
+	}
+	return PreReleaseVersion{ids: splitIdentifiers(s)}, nil
+}
+
+// PreReleaseVersion returns the structured view of the pre-release version of 'v'.
+// Like [SemVer.String], PreReleaseVersion does not validate 'v'.
+func (v SemVer) PreReleaseVersion() PreReleaseVersion {
+	return PreReleaseVersion{ids: splitIdentifiers(v.PreRelease)}
+}
+
+// Len returns the number of identifiers in 'p'.
+func (p PreReleaseVersion) Len() int {
+	return len(p.ids)
+}
+
+// Identifier returns the i-th identifier of 'p'.
+// Identifier panics if 'i' is out of range.
+func (p PreReleaseVersion) Identifier(i int) Identifier {
+	return p.ids[i]
+}
+
+// Identifiers returns a copy of the identifiers of 'p'.
+func (p PreReleaseVersion) Identifiers() []Identifier {
+	return append([]Identifier(nil), p.ids...)
+}
+
+// String implements the [fmt.Stringer] interface.
+// String returns the identifiers of 'p' joined with '.', suitable for [SemVer.PreRelease].
+func (p PreReleaseVersion) String() string {
+	return joinIdentifiers(p.ids)
+}
+
+// Append returns 'p' with 'ids' appended.
+// Append returns an error if any of 'ids' is not a valid pre-release identifier.
+func (p PreReleaseVersion) Append(ids ...string) (PreReleaseVersion, error) {
+	r, err := appendIdentifiers(p.ids, true, ids)
+	if err != nil {
+		return PreReleaseVersion{}, err
+	}
+	return PreReleaseVersion{ids: r}, nil
+}
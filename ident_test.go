@@ -0,0 +1,111 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifier_Int64(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      Identifier
+		want    int64
+		wantErr bool
+	}{
+		{name: "01", id: "", wantErr: true},
+		{name: "02", id: "rc1", wantErr: true},
+		{name: "03", id: "-1", wantErr: true},
+		{name: "04", id: "99999999999999999999", wantErr: true},
+		{name: "1", id: "0", want: 0},
+		{name: "2", id: "42", want: 42},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.id.Int64()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Identifier.Int64() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Identifier.Int64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePreReleaseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []Identifier
+		wantErr bool
+	}{
+		{name: "01", s: ".", wantErr: true},
+		{name: "02", s: "rc.01", wantErr: true},
+		{name: "03", s: "rc_1", wantErr: true},
+		{name: "1", s: ""},
+		{name: "2", s: "rc.1", want: []Identifier{"rc", "1"}},
+		{name: "3", s: "x-y.0.-", want: []Identifier{"x-y", "0", "-"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePreReleaseVersion(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePreReleaseVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got.Identifiers(), tt.want) {
+				t.Errorf("ParsePreReleaseVersion() = %v, want %v", got.Identifiers(), tt.want)
+			}
+			if got.String() != tt.s && !tt.wantErr {
+				t.Errorf("PreReleaseVersion.String() = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestSemVer_PreReleaseVersion(t *testing.T) {
+	p := parseMust("1.0.0-alpha.1.x-2+b.3").PreReleaseVersion()
+	if p.Len() != 3 {
+		t.Fatalf("PreReleaseVersion.Len() = %v, want 3", p.Len())
+	}
+	wantNumeric := []bool{false, true, false}
+	for i, want := range wantNumeric {
+		if got := p.Identifier(i).IsNumeric(); got != want {
+			t.Errorf("Identifier(%d).IsNumeric() = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestPreReleaseVersion_Append(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       PreReleaseVersion
+		ids     []string
+		want    string
+		wantErr bool
+	}{
+		{name: "01", ids: []string{""}, wantErr: true},
+		{name: "02", ids: []string{"01"}, wantErr: true},
+		{name: "03", ids: []string{"a.b"}, wantErr: true},
+		{name: "1", ids: []string{"rc", "1"}, want: "rc.1"},
+		{name: "2", p: parseMust("1.0.0-beta").PreReleaseVersion(), ids: []string{"2"}, want: "beta.2"},
+		{name: "3", p: parseMust("1.0.0-beta").PreReleaseVersion(), want: "beta"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := tt.p.String()
+			got, err := tt.p.Append(tt.ids...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PreReleaseVersion.Append() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("PreReleaseVersion.Append() = %v, want %v", got, tt.want)
+			}
+			if tt.p.String() != orig {
+				t.Errorf("PreReleaseVersion.Append() modified receiver: %v, want %v", tt.p, orig)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package semver
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Index is a set of versions kept sorted by [precedence] answering queries in logarithmic time.
+// Versions of equal precedence and different build metadata are all kept in the order of [CompareStrict];
+// identical versions are kept once.
+// Like [Range], queries do not treat pre-release versions specially.
+//
+// Index's zero value is an empty index ready to use.
+// Index is safe for concurrent use: queries may run concurrently with each other and with [Index.Insert].
+//
+// [precedence]: https://semver.org/#spec-item-11
+type Index struct {
+	mu sync.RWMutex
+	vv []SemVer
+}
+
+// NewIndex returns an [Index] containing 'vv'.
+func NewIndex(vv ...SemVer) (*Index, error) {
+	x := new(Index)
+	if err := x.Insert(vv...); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Insert adds 'vv' to 'x'.
+// If any of 'vv' is invalid, Insert returns an error and adds nothing.
+func (x *Index) Insert(vv ...SemVer) error {
+	for _, v := range vv {
+		if err := Valid(v); err != nil {
+			return err
+		}
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	// a few versions are inserted in place, many are merged by sorting
+	if len(vv) <= len(x.vv)/8 {
+		for _, v := range vv {
+			i, found := slices.BinarySearchFunc(x.vv, v, compareStrictValid)
+			if !found {
+				x.vv = slices.Insert(x.vv, i, v)
+			}
+		}
+		return nil
+	}
+	x.vv = append(x.vv, vv...)
+	slices.SortFunc(x.vv, compareStrictValid)
+	x.vv = slices.CompactFunc(x.vv, func(a, b SemVer) bool { return a == b })
+	return nil
+}
+
+// Len returns the number of versions in 'x'.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.vv)
+}
+
+// All returns all versions of 'x' in ascending order.
+func (x *Index) All() []SemVer {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return slices.Clone(x.vv)
+}
+
+// Has reports whether 'x' contains a version identical to 'v' (including build metadata).
+func (x *Index) Has(v SemVer) bool {
+	if Valid(v) != nil {
+		return false
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	_, found := slices.BinarySearchFunc(x.vv, v, compareStrictValid)
+	return found
+}
+
+// lowerBound returns the index of the first version not lower than 'v' in precedence.
+func (x *Index) lowerBound(v SemVer) int {
+	return sort.Search(len(x.vv), func(i int) bool { return compareValid(x.vv[i], v) >= 0 })
+}
+
+// upperBound returns the index of the first version higher than 'v' in precedence.
+func (x *Index) upperBound(v SemVer) int {
+	return sort.Search(len(x.vv), func(i int) bool { return compareValid(x.vv[i], v) > 0 })
+}
+
+// bounds returns the indexes of the versions of 'x' belonging to 'iv'.
+func (x *Index) bounds(iv interval) (int, int) {
+	lo, hi := x.lowerBound(iv.lo), len(x.vv)
+	if iv.hasHi {
+		hi = x.lowerBound(iv.hi)
+	}
+	return lo, hi
+}
+
+// InRange returns the versions of 'x' belonging to 'r' in ascending order.
+func (x *Index) InRange(r Range) []SemVer {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	var vv []SemVer
+	for _, iv := range r.ivs {
+		lo, hi := x.bounds(iv)
+		vv = append(vv, x.vv[lo:hi]...)
+	}
+	return vv
+}
+
+// HighestIn returns the highest version of 'x' belonging to 'r'.
+// The boolean result is false if there is no such version.
+func (x *Index) HighestIn(r Range) (SemVer, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	for i := len(r.ivs) - 1; i >= 0; i-- {
+		if lo, hi := x.bounds(r.ivs[i]); lo < hi {
+			return x.vv[hi-1], true
+		}
+	}
+	return SemVer{}, false
+}
+
+// HighestBelow returns the highest version of 'x' lower than 'v' in precedence (floor).
+// The boolean result is false if there is no such version or 'v' is invalid.
+func (x *Index) HighestBelow(v SemVer) (SemVer, bool) {
+	if Valid(v) != nil {
+		return SemVer{}, false
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if i := x.lowerBound(v); i > 0 {
+		return x.vv[i-1], true
+	}
+	return SemVer{}, false
+}
+
+// LowestAbove returns the lowest version of 'x' higher than 'v' in precedence (ceiling).
+// The boolean result is false if there is no such version or 'v' is invalid.
+func (x *Index) LowestAbove(v SemVer) (SemVer, bool) {
+	if Valid(v) != nil {
+		return SemVer{}, false
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if i := x.upperBound(v); i < len(x.vv) {
+		return x.vv[i], true
+	}
+	return SemVer{}, false
+}
+
+// majorEnd returns the index of the first version with major version greater than 'major'.
+func (x *Index) majorEnd(major int64) int {
+	return sort.Search(len(x.vv), func(i int) bool { return x.vv[i].Major > major })
+}
+
+// minorEnd returns the index of the first version with major.minor greater than 'major'.'minor'.
+func (x *Index) minorEnd(major, minor int64) int {
+	return sort.Search(len(x.vv), func(i int) bool {
+		v := x.vv[i]
+		return v.Major > major || v.Major == major && v.Minor > minor
+	})
+}
+
+// Latest returns the highest version of 'x' with major version 'major'.
+// The boolean result is false if there is no such version.
+func (x *Index) Latest(major int64) (SemVer, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if i := x.majorEnd(major); i > 0 && x.vv[i-1].Major == major {
+		return x.vv[i-1], true
+	}
+	return SemVer{}, false
+}
+
+// LatestMinor returns the highest version of 'x' with major and minor versions 'major' and 'minor'.
+// The boolean result is false if there is no such version.
+func (x *Index) LatestMinor(major, minor int64) (SemVer, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if i := x.minorEnd(major, minor); i > 0 && x.vv[i-1].Major == major && x.vv[i-1].Minor == minor {
+		return x.vv[i-1], true
+	}
+	return SemVer{}, false
+}
+
+// LatestPerMajor returns the highest version of each major version of 'x' in ascending order.
+func (x *Index) LatestPerMajor() []SemVer {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	var vv []SemVer
+	for i := 0; i < len(x.vv); {
+		i = x.majorEnd(x.vv[i].Major)
+		vv = append(vv, x.vv[i-1])
+	}
+	return vv
+}
+
+// LatestPerMinor returns the highest version of each major.minor version of 'x' in ascending order.
+func (x *Index) LatestPerMinor() []SemVer {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	var vv []SemVer
+	for i := 0; i < len(x.vv); {
+		i = x.minorEnd(x.vv[i].Major, x.vv[i].Minor)
+		vv = append(vv, x.vv[i-1])
+	}
+	return vv
+}
+
+// Prefix returns the versions of 'x' matching 'pattern' in ascending order.
+// The pattern is a partial version with optional trailing wildcards ('x', 'X' or '*'):
+// "1.4.*" and "1.4" match all versions 1.4.z including their pre-release versions,
+// "1.4.2" matches 1.4.2 and its pre-release versions, "1.4.2-rc.1" matches 1.4.2-rc.1 only,
+// "*" matches all versions.
+func (x *Index) Prefix(pattern string) ([]SemVer, error) {
+	if strings.Contains(pattern, "+") {
+		return nil, errors.New("malformed prefix")
+	}
+	p, err := parsePartial(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.v.PreRelease) > 0 {
+		return x.InRange(ExactRange(p.v)), nil
+	}
+	iv := interval{lo: p.lower()}
+	if p.n > 0 {
+		iv.lo.PreRelease = minVersion.PreRelease
+		iv.hi, iv.hasHi = p.nextUpper()
+	}
+	return x.InRange(Range{ivs: []interval{iv}}), nil
+}
@@ -0,0 +1,275 @@
+package semver
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestIndex(t *testing.T, ss ...string) *Index {
+	t.Helper()
+	vv := make([]SemVer, len(ss))
+	for i, s := range ss {
+		vv[i] = mustParse(t, s)
+	}
+	x, err := NewIndex(vv...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x
+}
+
+func versionStrings(vv []SemVer) string {
+	ss := make([]string, len(vv))
+	for i, v := range vv {
+		ss[i] = fmt.Sprintf("%v", v)
+	}
+	return strings.Join(ss, " ")
+}
+
+var indexVersions = []string{
+	"2.0.0", "1.4.0-rc.1", "1.4.0", "0.9.1", "1.3.7", "1.4.2", "1.4.2+b", "1.4.2+a",
+	"1.5.0-beta", "1.10.0", "3.0.0-alpha", "1.4.2", "0.1.0",
+}
+
+func TestIndex_Insert(t *testing.T) {
+	x := newTestIndex(t, indexVersions...)
+	want := "0.1.0 0.9.1 1.3.7 1.4.0-rc.1 1.4.0 1.4.2 1.4.2+a 1.4.2+b 1.5.0-beta 1.10.0 2.0.0 3.0.0-alpha"
+	if got := versionStrings(x.All()); got != want {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if err := x.Insert(SemVer{Major: 4}, SemVer{Major: -1}); err == nil {
+		t.Errorf("Insert() error = nil")
+	}
+	if got := x.Len(); got != 12 {
+		t.Errorf("Len() = %d, want 12", got)
+	}
+	if err := x.Insert(SemVer{Major: 1, Minor: 4, Patch: 1}, SemVer{Major: 2}); err != nil {
+		t.Fatal(err)
+	}
+	want = "0.1.0 0.9.1 1.3.7 1.4.0-rc.1 1.4.0 1.4.1 1.4.2 1.4.2+a 1.4.2+b 1.5.0-beta 1.10.0 2.0.0 3.0.0-alpha"
+	if got := versionStrings(x.All()); got != want {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if !x.Has(SemVer{Major: 1, Minor: 4, Patch: 2, Build: "a"}) || x.Has(SemVer{Major: 1, Minor: 4, Patch: 2, Build: "c"}) {
+		t.Errorf("Has() does not take build metadata into account")
+	}
+}
+
+func TestIndex_InRange(t *testing.T) {
+	x := newTestIndex(t, indexVersions...)
+	tests := []struct {
+		r    string
+		want string
+		high string
+	}{
+		{r: ">=1.4.0 <2.0.0-0", want: "1.4.0 1.4.2 1.4.2+a 1.4.2+b 1.5.0-beta 1.10.0", high: "1.10.0"},
+		{r: "^1.4", want: "1.4.0 1.4.2 1.4.2+a 1.4.2+b 1.5.0-beta 1.10.0", high: "1.10.0"},
+		{r: "~1.4.0 || >=3.0.0-0", want: "1.4.0 1.4.2 1.4.2+a 1.4.2+b 3.0.0-alpha", high: "3.0.0-alpha"},
+		{r: "<1", want: "0.1.0 0.9.1", high: "0.9.1"},
+		{r: "1.4.2", want: "1.4.2 1.4.2+a 1.4.2+b", high: "1.4.2+b"},
+		{r: ">3", want: "", high: ""},
+		{r: "*", want: "0.1.0 0.9.1 1.3.7 1.4.0-rc.1 1.4.0 1.4.2 1.4.2+a 1.4.2+b 1.5.0-beta 1.10.0 2.0.0 3.0.0-alpha", high: "3.0.0-alpha"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.r, func(t *testing.T) {
+			r, err := ParseRange(tt.r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := versionStrings(x.InRange(r)); got != tt.want {
+				t.Errorf("InRange() = %v, want %v", got, tt.want)
+			}
+			high, ok := x.HighestIn(r)
+			if ok != (tt.high != "") || ok && fmt.Sprintf("%v", high) != tt.high {
+				t.Errorf("HighestIn() = %v, %v, want %v", high, ok, tt.high)
+			}
+		})
+	}
+}
+
+func TestIndex_HighestBelow_LowestAbove(t *testing.T) {
+	x := newTestIndex(t, indexVersions...)
+	tests := []struct {
+		v     string
+		below string
+		above string
+	}{
+		{v: "1.4.2", below: "1.4.0", above: "1.5.0-beta"},
+		{v: "1.4.2+x", below: "1.4.0", above: "1.5.0-beta"},
+		{v: "1.4.1", below: "1.4.0", above: "1.4.2"},
+		{v: "1.4.0", below: "1.4.0-rc.1", above: "1.4.2"},
+		{v: "0.1.0", below: "", above: "0.9.1"},
+		{v: "0.0.1", below: "", above: "0.1.0"},
+		{v: "3.0.0-alpha", below: "2.0.0", above: ""},
+		{v: "5.0.0", below: "3.0.0-alpha", above: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			v := mustParse(t, tt.v)
+			below, ok := x.HighestBelow(v)
+			if ok != (tt.below != "") || ok && fmt.Sprintf("%v", below) != tt.below {
+				t.Errorf("HighestBelow() = %v, %v, want %v", below, ok, tt.below)
+			}
+			above, ok := x.LowestAbove(v)
+			if ok != (tt.above != "") || ok && fmt.Sprintf("%v", above) != tt.above {
+				t.Errorf("LowestAbove() = %v, %v, want %v", above, ok, tt.above)
+			}
+		})
+	}
+	if _, ok := x.HighestBelow(SemVer{Major: -1}); ok {
+		t.Errorf("HighestBelow() of invalid version = true")
+	}
+}
+
+func TestIndex_Latest(t *testing.T) {
+	x := newTestIndex(t, indexVersions...)
+	if got, want := versionStrings(x.LatestPerMajor()), "0.9.1 1.10.0 2.0.0 3.0.0-alpha"; got != want {
+		t.Errorf("LatestPerMajor() = %v, want %v", got, want)
+	}
+	if got, want := versionStrings(x.LatestPerMinor()), "0.1.0 0.9.1 1.3.7 1.4.2+b 1.5.0-beta 1.10.0 2.0.0 3.0.0-alpha"; got != want {
+		t.Errorf("LatestPerMinor() = %v, want %v", got, want)
+	}
+	if v, ok := x.Latest(1); !ok || v.String() != "1.10.0" {
+		t.Errorf("Latest(1) = %v, %v", v, ok)
+	}
+	if v, ok := x.Latest(4); ok {
+		t.Errorf("Latest(4) = %v, %v", v, ok)
+	}
+	if v, ok := x.LatestMinor(1, 4); !ok || fmt.Sprintf("%v", v) != "1.4.2+b" {
+		t.Errorf("LatestMinor(1, 4) = %v, %v", v, ok)
+	}
+	if v, ok := x.LatestMinor(1, 6); ok {
+		t.Errorf("LatestMinor(1, 6) = %v, %v", v, ok)
+	}
+	var empty Index
+	if vv := empty.LatestPerMajor(); vv != nil {
+		t.Errorf("LatestPerMajor() of empty index = %v", vv)
+	}
+}
+
+func TestIndex_Prefix(t *testing.T) {
+	x := newTestIndex(t, indexVersions...)
+	tests := []struct {
+		pattern string
+		want    string
+		wantErr bool
+	}{
+		{pattern: "1.4.*", want: "1.4.0-rc.1 1.4.0 1.4.2 1.4.2+a 1.4.2+b"},
+		{pattern: "1.4.x", want: "1.4.0-rc.1 1.4.0 1.4.2 1.4.2+a 1.4.2+b"},
+		{pattern: "1.4", want: "1.4.0-rc.1 1.4.0 1.4.2 1.4.2+a 1.4.2+b"},
+		{pattern: "1.4.0", want: "1.4.0-rc.1 1.4.0"},
+		{pattern: "1.4.0-rc.1", want: "1.4.0-rc.1"},
+		{pattern: "3.*", want: "3.0.0-alpha"},
+		{pattern: "0", want: "0.1.0 0.9.1"},
+		{pattern: "1.1", want: ""},
+		{pattern: "*", want: versionStrings(x.All())},
+		{pattern: "", wantErr: true},
+		{pattern: "1.*.2", wantErr: true},
+		{pattern: "1.4.2+a", wantErr: true},
+		{pattern: "a.b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := x.Prefix(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Prefix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && versionStrings(got) != tt.want {
+				t.Errorf("Prefix() = %v, want %v", versionStrings(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestIndex_Prefix_MaxInt64(t *testing.T) {
+	const max = "9223372036854775807"
+	x := newTestIndex(t, "1."+max+".0", "1."+max+"."+max, "2.0.0-rc.1", "2.0.0", "5.0.0")
+	for pattern, want := range map[string]string{
+		"1." + max:             "1." + max + ".0 1." + max + "." + max,
+		"1." + max + ".*":      "1." + max + ".0 1." + max + "." + max,
+		"1." + max + "." + max: "1." + max + "." + max,
+	} {
+		got, err := x.Prefix(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if versionStrings(got) != want {
+			t.Errorf("Prefix(%s) = %v, want %v", pattern, versionStrings(got), want)
+		}
+	}
+}
+
+func TestIndex_Concurrent(t *testing.T) {
+	var x Index
+	r, _ := ParseRange("^1")
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				if err := x.Insert(SemVer{Major: int64(g), Minor: int64(i)}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				vv := x.InRange(r)
+				for _, v := range vv {
+					if v.Major != 1 {
+						t.Errorf("InRange() = %v", v)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := x.Len(); got != 800 {
+		t.Errorf("Len() = %d, want 800", got)
+	}
+	if !slices.IsSortedFunc(x.All(), compareStrictValid) {
+		t.Errorf("All() is not sorted")
+	}
+}
+
+func BenchmarkIndex_InRange(b *testing.B) {
+	x, _ := NewIndex(benchVersions(50_000)...)
+	r, _ := ParseRange(">=1.4.0 <2.0.0-0")
+	b.ResetTimer()
+	for range b.N {
+		_ = x.InRange(r)
+	}
+}
+
+func BenchmarkIndex_Scan(b *testing.B) {
+	vv := benchVersions(50_000)
+	r, _ := ParseRange(">=1.4.0 <2.0.0-0")
+	b.ResetTimer()
+	for range b.N {
+		var got []SemVer
+		for _, v := range vv {
+			if r.Contains(v) {
+				got = append(got, v)
+			}
+		}
+		_ = got
+	}
+}
+
+func BenchmarkIndex_HighestBelow(b *testing.B) {
+	vv := benchVersions(50_000)
+	x, _ := NewIndex(vv...)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for range b.N {
+		_, _ = x.HighestBelow(vv[r.Intn(len(vv))])
+	}
+}
@@ -0,0 +1,117 @@
+package semver
+
+import (
+	"sync"
+)
+
+// Handle is a compact representation of a valid [SemVer] interned by a [Table].
+//
+// A release version (without pre-release version and build metadata) with
+// version numbers below 2^21 is packed into the Handle itself:
+// major, minor and patch versions occupy 21 bits each, most significant first.
+// Other versions are stored once in the side table of the [Table],
+// and the Handle holds their index with the top bit set.
+//
+// Handles of the same [Table] are equal if and only if the versions are identical
+// (including build metadata), so Handle may be used as a map key.
+// Handle's zero value is "0.0.0" version.
+type Handle uint64
+
+const (
+	handleBits    = 21
+	handleMax     = 1<<handleBits - 1
+	handleSideBit = 1 << 63
+)
+
+// pack returns the packed Handle of 'v' if 'v' fits into it.
+func pack(v SemVer) (Handle, bool) {
+	if len(v.PreRelease) > 0 || len(v.Build) > 0 ||
+		v.Major > handleMax || v.Minor > handleMax || v.Patch > handleMax {
+		return 0, false
+	}
+	return Handle(v.Major<<(2*handleBits) | v.Minor<<handleBits | v.Patch), true
+}
+
+// IsPacked reports whether 'h' holds the version itself rather than a side table index.
+func (h Handle) IsPacked() bool {
+	return h&handleSideBit == 0
+}
+
+func (h Handle) unpack() SemVer {
+	return SemVer{
+		Major: int64(h >> (2 * handleBits)),
+		Minor: int64(h >> handleBits & handleMax),
+		Patch: int64(h & handleMax),
+	}
+}
+
+// Table interns versions as [Handle]s.
+// Table's zero value is an empty table ready to use.
+// Table is safe for concurrent use.
+type Table struct {
+	mu    sync.RWMutex
+	index map[SemVer]Handle
+	side  []SemVer
+}
+
+// Intern returns the [Handle] of 'v'.
+// Interning identical versions returns the same [Handle].
+func (t *Table) Intern(v SemVer) (Handle, error) {
+	if err := Valid(v); err != nil {
+		return 0, err
+	}
+	if h, ok := pack(v); ok {
+		return h, nil
+	}
+	t.mu.RLock()
+	h, ok := t.index[v]
+	t.mu.RUnlock()
+	if ok {
+		return h, nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if h, ok := t.index[v]; ok {
+		return h, nil
+	}
+	if t.index == nil {
+		t.index = make(map[SemVer]Handle)
+	}
+	h = Handle(len(t.side)) | handleSideBit
+	t.side = append(t.side, v)
+	t.index[v] = h
+	return h, nil
+}
+
+// SemVer returns the version of 'h'.
+// 'h' must be returned by [Table.Intern] of 't', otherwise SemVer panics.
+func (t *Table) SemVer(h Handle) SemVer {
+	if h.IsPacked() {
+		return h.unpack()
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.side[h&^handleSideBit]
+}
+
+// Compare [compares] the versions of 'h1' and 'h2' like [Compare].
+// Packed handles are compared without unpacking.
+// 'h1' and 'h2' must be returned by [Table.Intern] of 't', otherwise Compare panics.
+//
+// [compares]: https://semver.org/#spec-item-11
+func (t *Table) Compare(h1, h2 Handle) int {
+	if h1 == h2 {
+		return 0
+	}
+	if h1.IsPacked() && h2.IsPacked() {
+		return boolToCompareResult(h1 > h2)
+	}
+	return compareValid(t.SemVer(h1), t.SemVer(h2))
+}
+
+// Len returns the number of versions stored in the side table of 't'.
+func (t *Table) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.side)
+}
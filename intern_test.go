@@ -0,0 +1,205 @@
+package semver
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestTable_Intern(t *testing.T) {
+	tests := []struct {
+		name       string
+		v          SemVer
+		wantPacked bool
+		wantErr    bool
+	}{
+		{name: "zero", v: SemVer{}, wantPacked: true},
+		{name: "release", v: SemVer{Major: 1, Minor: 2, Patch: 3}, wantPacked: true},
+		{name: "max packed", v: SemVer{Major: 1<<21 - 1, Minor: 1<<21 - 1, Patch: 1<<21 - 1}, wantPacked: true},
+		{name: "large major", v: SemVer{Major: 1 << 21}},
+		{name: "large patch", v: SemVer{Patch: 1<<63 - 1}},
+		{name: "pre-release", v: SemVer{Major: 1, PreRelease: "rc.1"}},
+		{name: "build", v: SemVer{Major: 1, Build: "b"}},
+		{name: "invalid", v: SemVer{Major: -1}, wantErr: true},
+	}
+	var tab Table
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := tab.Intern(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Intern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if h.IsPacked() != tt.wantPacked {
+				t.Errorf("Handle.IsPacked() = %v, want %v", h.IsPacked(), tt.wantPacked)
+			}
+			if got := tab.SemVer(h); got != tt.v {
+				t.Errorf("SemVer() = %v, want %v", got, tt.v)
+			}
+			if h2, _ := tab.Intern(tt.v); h2 != h {
+				t.Errorf("Intern() again = %x, want %x", h2, h)
+			}
+		})
+	}
+	if got, want := tab.Len(), 4; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+}
+
+func TestTable_Identity(t *testing.T) {
+	var tab Table
+	h1, _ := tab.Intern(SemVer{Major: 1, Build: "a"})
+	h2, _ := tab.Intern(SemVer{Major: 1, Build: "b"})
+	if h1 == h2 {
+		t.Errorf("handles of versions with different build metadata are equal")
+	}
+	if r := tab.Compare(h1, h2); r != 0 {
+		t.Errorf("Compare() = %d, want 0", r)
+	}
+}
+
+func TestTable_Compare(t *testing.T) {
+	ss := []string{
+		"0.0.0", "0.0.1", "0.1.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta.11",
+		"1.0.0", "1.0.1", "1.2097151.0", "1.2097152.0", "2.0.0-rc.1", "2.0.0",
+		"2097151.0.0", "2097152.0.0-0", "2097152.0.0", "9223372036854775807.0.0",
+	}
+	var tab Table
+	hh := make([]Handle, len(ss))
+	for i, s := range ss {
+		hh[i], _ = tab.Intern(mustParse(t, s))
+	}
+	for i := range hh {
+		for j := range hh {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := tab.Compare(hh[i], hh[j]); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", ss[i], ss[j], got, want)
+			}
+		}
+	}
+}
+
+func TestTable_Concurrent(t *testing.T) {
+	var tab Table
+	var wg sync.WaitGroup
+	hh := make([][]Handle, 8)
+	for g := range hh {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				h, err := tab.Intern(SemVer{Major: int64(i), PreRelease: "rc"})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				hh[g] = append(hh[g], h)
+			}
+		}()
+	}
+	wg.Wait()
+	for g := 1; g < len(hh); g++ {
+		for i := range hh[g] {
+			if hh[g][i] != hh[0][i] {
+				t.Fatalf("goroutines %d and 0 got different handles for version %d", g, i)
+			}
+		}
+	}
+	if got := tab.Len(); got != 100 {
+		t.Errorf("Len() = %d, want 100", got)
+	}
+}
+
+// benchVersions returns 'n' versions typical for a registry:
+// mostly small release versions, some pre-release versions and build metadata.
+func benchVersions(n int) []SemVer {
+	r := rand.New(rand.NewSource(1))
+	vv := make([]SemVer, n)
+	for i := range vv {
+		v := SemVer{Major: r.Int63n(20), Minor: r.Int63n(50), Patch: r.Int63n(100)}
+		switch r.Intn(20) {
+		case 0:
+			v.PreRelease = fmt.Sprintf("rc.%d", r.Intn(5))
+		case 1:
+			v.Build = fmt.Sprintf("%07x", r.Int31())
+		}
+		vv[i] = v
+	}
+	return vv
+}
+
+// heapBytes returns the heap size after a garbage collection.
+func heapBytes() uint64 {
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
+const benchN = 100_000
+
+func BenchmarkMemory_SemVers(b *testing.B) {
+	src := benchVersions(benchN)
+	b.ResetTimer()
+	var bytes uint64
+	for range b.N {
+		before := heapBytes()
+		vv := make([]SemVer, len(src))
+		for i, v := range src {
+			// strings are copied to account for them as a registry would after parsing
+			vv[i] = SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch,
+				PreRelease: string([]byte(v.PreRelease)), Build: string([]byte(v.Build))}
+		}
+		bytes += heapBytes() - before
+		runtime.KeepAlive(vv)
+	}
+	b.ReportMetric(float64(bytes)/float64(b.N)/benchN, "bytes/version")
+}
+
+func BenchmarkMemory_Handles(b *testing.B) {
+	src := benchVersions(benchN)
+	b.ResetTimer()
+	var bytes uint64
+	for range b.N {
+		before := heapBytes()
+		tab := new(Table)
+		hh := make([]Handle, len(src))
+		for i, v := range src {
+			hh[i], _ = tab.Intern(SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch,
+				PreRelease: string([]byte(v.PreRelease)), Build: string([]byte(v.Build))})
+		}
+		bytes += heapBytes() - before
+		runtime.KeepAlive(hh)
+		runtime.KeepAlive(tab)
+	}
+	b.ReportMetric(float64(bytes)/float64(b.N)/benchN, "bytes/version")
+}
+
+func BenchmarkCompare_SemVers(b *testing.B) {
+	vv := benchVersions(1024)
+	b.ResetTimer()
+	for i := range b.N {
+		_, _ = Compare(vv[i%1024], vv[(i+1)%1024])
+	}
+}
+
+func BenchmarkCompare_Handles(b *testing.B) {
+	var tab Table
+	hh := make([]Handle, 1024)
+	for i, v := range benchVersions(len(hh)) {
+		hh[i], _ = tab.Intern(v)
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		_ = tab.Compare(hh[i%1024], hh[(i+1)%1024])
+	}
+}
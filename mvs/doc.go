@@ -0,0 +1,10 @@
+// Package mvs implements [Minimal Version Selection] over [semver.SemVer] versions.
+//
+// Modules are identified by path and version; the requirement graph is provided by [Reqs].
+// [Build] computes the build list of a target module,
+// [Upgrade], [UpgradeAll] and [Downgrade] compute modified build lists,
+// and [BuildList.Why] explains why a version was selected.
+// Versions are ordered with [semver.Compare].
+//
+// [Minimal Version Selection]: https://research.swtch.com/vgo-mvs
+package mvs
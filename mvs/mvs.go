@@ -0,0 +1,455 @@
+package mvs
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/solsw/semver"
+)
+
+// Module is a module version.
+type Module struct {
+	Path    string
+	Version semver.SemVer
+}
+
+// String implements the [fmt.Stringer] interface.
+// String returns "path@version".
+func (m Module) String() string {
+	return m.Path + "@" + m.Version.String()
+}
+
+// Options configure the computation of a build list.
+// A nil *Options is valid and means no exclusions and replacements.
+type Options struct {
+	// Exclude lists module versions that must not be selected.
+	// As in Go, a requirement on an excluded version is treated as a requirement
+	// on the next higher available version that is not excluded.
+	Exclude []Module
+	// Replace maps a module version to the module whose requirements are used instead.
+	Replace map[Module]Module
+	// ReplacePath maps a module path to the module whose requirements are used instead
+	// for all versions of the path. Replace takes precedence over ReplacePath.
+	ReplacePath map[string]Module
+}
+
+func (o *Options) excluded(m Module) bool {
+	return o != nil && slices.Contains(o.Exclude, m)
+}
+
+func (o *Options) replacement(m Module) Module {
+	if o == nil {
+		return m
+	}
+	if r, ok := o.Replace[m]; ok {
+		return r
+	}
+	if r, ok := o.ReplacePath[m.Path]; ok {
+		return r
+	}
+	return m
+}
+
+// Reason explains why a version was selected.
+type Reason struct {
+	// Chain is the shortest chain of requirements leading to the selected version:
+	// Chain[0] is the target and each module requires the next one.
+	Chain []Module
+	// Required is the version the last but one module of Chain requires.
+	// It differs from the selected version if the required version is excluded.
+	Required semver.SemVer
+	// Retained reports that the selected version is not required by any module
+	// but kept from the build list before [Downgrade]; Chain is then the target and the module.
+	Retained bool
+}
+
+// String implements the [fmt.Stringer] interface.
+// String returns an empty string for a Reason with an empty Chain.
+func (r Reason) String() string {
+	if len(r.Chain) == 0 {
+		return ""
+	}
+	ss := make([]string, len(r.Chain))
+	for i, m := range r.Chain {
+		ss[i] = m.String()
+	}
+	sep := " requires "
+	if r.Retained {
+		sep = " retains "
+	}
+	s := strings.Join(ss, sep)
+	if last := r.Chain[len(r.Chain)-1]; last.Version != r.Required {
+		s += fmt.Sprintf(" (%s is excluded)", Module{Path: last.Path, Version: r.Required})
+	}
+	return s
+}
+
+// BuildList is the list of module versions selected for a target module.
+type BuildList struct {
+	modules []Module
+	reasons map[string]Reason
+}
+
+// Modules returns the selected module versions: the target first, then the others sorted by path.
+func (bl *BuildList) Modules() []Module {
+	return slices.Clone(bl.modules)
+}
+
+// Version returns the selected version of the module 'path'.
+// The boolean result reports whether the module is in the build list.
+func (bl *BuildList) Version(path string) (semver.SemVer, bool) {
+	for _, m := range bl.modules {
+		if m.Path == path {
+			return m.Version, true
+		}
+	}
+	return semver.SemVer{}, false
+}
+
+// Why explains why the version of the module 'path' was selected.
+// The boolean result reports whether the module is in the build list.
+func (bl *BuildList) Why(path string) (Reason, bool) {
+	r, ok := bl.reasons[path]
+	return r, ok
+}
+
+// graph walks the requirement graph with exclusions and replacements applied.
+type graph struct {
+	reqs Reqs
+	opts *Options
+	// versions caches valid available versions in ascending order.
+	versions map[string][]semver.SemVer
+}
+
+func compareVersions(a, b semver.SemVer) int {
+	r, _ := semver.Compare(a, b)
+	return r
+}
+
+func (g *graph) required(m Module) ([]Module, error) {
+	rr, err := g.reqs.Required(g.opts.replacement(m))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rr {
+		if err := semver.Valid(r.Version); err != nil {
+			return nil, fmt.Errorf("%s requires %s: %w", m, r, err)
+		}
+	}
+	return rr, nil
+}
+
+func (g *graph) available(path string) ([]semver.SemVer, error) {
+	if vv, ok := g.versions[path]; ok {
+		return vv, nil
+	}
+	all, err := g.reqs.Versions(path)
+	if err != nil {
+		return nil, err
+	}
+	var vv []semver.SemVer
+	for _, v := range all {
+		if v.IsValid() && !g.opts.excluded(Module{Path: path, Version: v}) {
+			vv = append(vv, v)
+		}
+	}
+	slices.SortFunc(vv, compareVersions)
+	g.versions[path] = vv
+	return vv, nil
+}
+
+// allowed returns 'm' or, if 'm' is excluded, the next higher allowed version.
+func (g *graph) allowed(m Module) (Module, error) {
+	if !g.opts.excluded(m) {
+		return m, nil
+	}
+	vv, err := g.available(m.Path)
+	if err != nil {
+		return Module{}, err
+	}
+	for _, v := range vv {
+		if compareVersions(v, m.Version) > 0 {
+			return Module{Path: m.Path, Version: v}, nil
+		}
+	}
+	return Module{}, fmt.Errorf("%s is excluded and there is no higher version", m)
+}
+
+// previous returns the highest allowed version of 'm.Path' lower than 'm'.
+// The boolean result is false if there is no such version.
+func (g *graph) previous(m Module) (Module, bool, error) {
+	vv, err := g.available(m.Path)
+	if err != nil {
+		return Module{}, false, err
+	}
+	for i := len(vv) - 1; i >= 0; i-- {
+		if compareVersions(vv[i], m.Version) < 0 {
+			return Module{Path: m.Path, Version: vv[i]}, true, nil
+		}
+	}
+	return Module{}, false, nil
+}
+
+// latest returns the highest allowed release version of 'path',
+// or the highest allowed version if there are only pre-release versions.
+func (g *graph) latest(path string) (semver.SemVer, bool, error) {
+	vv, err := g.available(path)
+	if err != nil || len(vv) == 0 {
+		return semver.SemVer{}, false, err
+	}
+	for i := len(vv) - 1; i >= 0; i-- {
+		if len(vv[i].PreRelease) == 0 {
+			return vv[i], true, nil
+		}
+	}
+	return vv[len(vv)-1], true, nil
+}
+
+// buildList computes the build list of 'target' whose requirements are 'roots'.
+// The modules of 'retained' are added to the build list, unless required at the same version.
+func (g *graph) buildList(target Module, roots, retained []Module) (*BuildList, error) {
+	type node struct {
+		m        Module
+		parent   *node
+		required semver.SemVer
+		retained bool
+	}
+	rootNode := &node{m: target, required: target.Version}
+	seen := map[Module]*node{target: rootNode}
+	var queue []*node
+	enqueue := func(parent *node, r Module) error {
+		if err := semver.Valid(r.Version); err != nil {
+			return fmt.Errorf("%s requires %s: %w", parent.m, r, err)
+		}
+		a, err := g.allowed(r)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[a]; !ok {
+			n := &node{m: a, parent: parent, required: r.Version}
+			seen[a] = n
+			queue = append(queue, n)
+		}
+		return nil
+	}
+	walk := func() error {
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			rr, err := g.required(n.m)
+			if err != nil {
+				return err
+			}
+			for _, r := range rr {
+				if err := enqueue(n, r); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, r := range roots {
+		if err := enqueue(rootNode, r); err != nil {
+			return nil, err
+		}
+	}
+	if err := walk(); err != nil {
+		return nil, err
+	}
+	// retained modules are added after all required ones, so that required ones keep their chains
+	for _, m := range retained {
+		if _, ok := seen[m]; !ok {
+			n := &node{m: m, parent: rootNode, required: m.Version, retained: true}
+			seen[m] = n
+			queue = append(queue, n)
+		}
+	}
+	if err := walk(); err != nil {
+		return nil, err
+	}
+	// the maximum version of every path, the target's path being fixed
+	selected := make(map[string]*node)
+	for m, n := range seen {
+		if m.Path == target.Path {
+			continue
+		}
+		cur, ok := selected[m.Path]
+		if !ok {
+			selected[m.Path] = n
+			continue
+		}
+		c := compareVersions(m.Version, cur.m.Version)
+		// versions differing in build metadata only are ordered by text for determinism
+		if c > 0 || (c == 0 && m.Version.String() > cur.m.Version.String()) {
+			selected[m.Path] = n
+		}
+	}
+	bl := &BuildList{
+		modules: []Module{target},
+		reasons: map[string]Reason{target.Path: {Chain: []Module{target}, Required: target.Version}},
+	}
+	for _, path := range slices.Sorted(maps.Keys(selected)) {
+		n := selected[path]
+		bl.modules = append(bl.modules, n.m)
+		var chain []Module
+		for p := n; p != nil; p = p.parent {
+			chain = append(chain, p.m)
+		}
+		slices.Reverse(chain)
+		bl.reasons[path] = Reason{Chain: chain, Required: n.required, Retained: n.retained}
+	}
+	return bl, nil
+}
+
+func newGraph(reqs Reqs, opts *Options) *graph {
+	return &graph{reqs: reqs, opts: opts, versions: make(map[string][]semver.SemVer)}
+}
+
+// Build computes the build list of 'target':
+// the maximum required version of every module reachable from 'target'.
+// 'target' itself is always selected at its own version.
+func Build(target Module, reqs Reqs, opts *Options) (*BuildList, error) {
+	g := newGraph(reqs, opts)
+	roots, err := g.required(target)
+	if err != nil {
+		return nil, err
+	}
+	return g.buildList(target, roots, nil)
+}
+
+// Upgrade computes the build list of 'target' as if 'target' additionally required 'upgrades'.
+func Upgrade(target Module, reqs Reqs, opts *Options, upgrades ...Module) (*BuildList, error) {
+	g := newGraph(reqs, opts)
+	roots, err := g.required(target)
+	if err != nil {
+		return nil, err
+	}
+	return g.buildList(target, slices.Concat(roots, upgrades), nil)
+}
+
+// UpgradeAll computes the build list of 'target' with every module of its build list
+// upgraded to the latest available version (the highest release version,
+// or the highest pre-release version if there are no release versions).
+func UpgradeAll(target Module, reqs Reqs, opts *Options) (*BuildList, error) {
+	bl, err := Build(target, reqs, opts)
+	if err != nil {
+		return nil, err
+	}
+	g := newGraph(reqs, opts)
+	var upgrades []Module
+	for _, m := range bl.modules[1:] {
+		v, ok, err := g.latest(m.Path)
+		if err != nil {
+			return nil, err
+		}
+		if ok && compareVersions(v, m.Version) > 0 {
+			upgrades = append(upgrades, Module{Path: m.Path, Version: v})
+		}
+	}
+	return Upgrade(target, reqs, opts, upgrades...)
+}
+
+// Downgrade computes the build list of 'target' with the modules of 'downgrades'
+// selected at no higher than the given versions.
+// Modules requiring higher versions of the downgraded modules are downgraded
+// to their highest versions not requiring them, or removed if there are no such versions.
+// The target is taken to require the downgraded versions of its requirements;
+// other modules are kept at their downgraded versions and reported by [BuildList.Why] as retained
+// unless these versions are still required.
+func Downgrade(target Module, reqs Reqs, opts *Options, downgrades ...Module) (*BuildList, error) {
+	bl, err := Build(target, reqs, opts)
+	if err != nil {
+		return nil, err
+	}
+	g := newGraph(reqs, opts)
+	limit := make(map[string]semver.SemVer)
+	for _, m := range bl.modules[1:] {
+		limit[m.Path] = m.Version
+	}
+	for _, d := range downgrades {
+		if err := semver.Valid(d.Version); err != nil {
+			return nil, fmt.Errorf("downgrade %s: %w", d, err)
+		}
+		if v, ok := limit[d.Path]; !ok || compareVersions(d.Version, v) < 0 {
+			limit[d.Path] = d.Version
+		}
+	}
+	added := make(map[Module]bool)
+	disallowed := make(map[Module]bool)
+	rdeps := make(map[Module][]Module)
+	var disallow func(m Module)
+	disallow = func(m Module) {
+		if disallowed[m] {
+			return
+		}
+		disallowed[m] = true
+		for _, p := range rdeps[m] {
+			disallow(p)
+		}
+	}
+	// add walks the requirements of 'm', disallowing it if it requires a disallowed version
+	var add func(m Module) error
+	add = func(m Module) error {
+		if added[m] {
+			return nil
+		}
+		added[m] = true
+		if v, ok := limit[m.Path]; ok && compareVersions(m.Version, v) > 0 {
+			disallow(m)
+			return nil
+		}
+		rr, err := g.required(m)
+		if err != nil {
+			return err
+		}
+		for _, r := range rr {
+			a, err := g.allowed(r)
+			if err != nil {
+				return err
+			}
+			if err := add(a); err != nil {
+				return err
+			}
+			if disallowed[a] {
+				disallow(m)
+				return nil
+			}
+			rdeps[a] = append(rdeps[a], m)
+		}
+		return nil
+	}
+	var retained []Module
+next:
+	for _, m := range bl.modules[1:] {
+		if err := add(m); err != nil {
+			return nil, err
+		}
+		for disallowed[m] {
+			p, ok, err := g.previous(m)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue next
+			}
+			if err := add(p); err != nil {
+				return nil, err
+			}
+			m = p
+		}
+		retained = append(retained, m)
+	}
+	rr, err := g.required(target)
+	if err != nil {
+		return nil, err
+	}
+	var roots []Module
+	for _, r := range rr {
+		if i := slices.IndexFunc(retained, func(m Module) bool { return m.Path == r.Path }); i >= 0 {
+			roots = append(roots, retained[i])
+		}
+	}
+	return g.buildList(target, roots, retained)
+}
@@ -0,0 +1,348 @@
+package mvs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/solsw/semver"
+)
+
+// mod parses "path version".
+func mod(t *testing.T, s string) Module {
+	t.Helper()
+	path, version, _ := strings.Cut(s, " ")
+	v, err := semver.Parse(version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Module{Path: path, Version: v}
+}
+
+func mods(t *testing.T, ss ...string) []Module {
+	t.Helper()
+	var mm []Module
+	for _, s := range ss {
+		mm = append(mm, mod(t, s))
+	}
+	return mm
+}
+
+func newTestGraph(t *testing.T, g map[string][]string) *Graph {
+	t.Helper()
+	var r Graph
+	for m, reqs := range g {
+		r.Add(mod(t, m), mods(t, reqs...)...)
+	}
+	return &r
+}
+
+// blog is the example from the Minimal Version Selection article.
+var blog = map[string][]string{
+	"a 1.0.0": {"b 1.2.0", "c 1.2.0"},
+	"b 1.1.0": {"d 1.1.0"},
+	"b 1.2.0": {"d 1.3.0"},
+	"c 1.1.0": nil,
+	"c 1.2.0": {"d 1.4.0"},
+	"c 1.3.0": {"f 1.1.0"},
+	"d 1.1.0": {"e 1.1.0"},
+	"d 1.2.0": {"e 1.1.0"},
+	"d 1.3.0": {"e 1.2.0"},
+	"d 1.4.0": {"e 1.2.0"},
+	"e 1.1.0": nil,
+	"e 1.2.0": nil,
+	"e 1.3.0": nil,
+	"f 1.1.0": {"g 1.1.0"},
+	"g 1.1.0": {"f 1.1.0"},
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name string
+		g    map[string][]string
+		opts func(t *testing.T) *Options
+		want []string
+	}{
+		{name: "blog",
+			g:    blog,
+			want: []string{"a 1.0.0", "b 1.2.0", "c 1.2.0", "d 1.4.0", "e 1.2.0"},
+		},
+		{name: "no requirements",
+			g:    map[string][]string{"a 1.0.0": nil},
+			want: []string{"a 1.0.0"},
+		},
+		{name: "cycle back to target",
+			g: map[string][]string{
+				"a 1.0.0": {"b 1.0.0"},
+				"b 1.0.0": {"a 2.0.0"},
+				"a 2.0.0": {"c 1.0.0"},
+				"c 1.0.0": nil,
+			},
+			want: []string{"a 1.0.0", "b 1.0.0", "c 1.0.0"},
+		},
+		{name: "pre-release is lower than release",
+			g: map[string][]string{
+				"a 1.0.0":      {"b 1.0.0", "c 1.0.0"},
+				"b 1.0.0":      {"d 1.1.0-rc.1"},
+				"c 1.0.0":      {"d 1.0.0"},
+				"d 1.0.0":      nil,
+				"d 1.1.0-rc.1": nil,
+			},
+			want: []string{"a 1.0.0", "b 1.0.0", "c 1.0.0", "d 1.1.0-rc.1"},
+		},
+		{name: "exclusion",
+			g: blog,
+			opts: func(t *testing.T) *Options {
+				return &Options{Exclude: mods(t, "c 1.2.0")}
+			},
+			want: []string{"a 1.0.0", "b 1.2.0", "c 1.3.0", "d 1.3.0", "e 1.2.0", "f 1.1.0", "g 1.1.0"},
+		},
+		{name: "exclusion bumps to next version",
+			g: map[string][]string{
+				"a 1.0.0": {"b 1.1.0"},
+				"b 1.1.0": nil,
+				"b 1.2.0": {"c 1.0.0"},
+				"c 1.0.0": nil,
+			},
+			opts: func(t *testing.T) *Options {
+				return &Options{Exclude: mods(t, "b 1.1.0")}
+			},
+			want: []string{"a 1.0.0", "b 1.2.0", "c 1.0.0"},
+		},
+		{name: "replacement",
+			g: map[string][]string{
+				"a 1.0.0":    {"b 1.0.0"},
+				"b 1.0.0":    {"c 1.0.0"},
+				"fork 1.0.0": {"c 1.1.0"},
+				"c 1.0.0":    nil,
+				"c 1.1.0":    nil,
+			},
+			opts: func(t *testing.T) *Options {
+				return &Options{Replace: map[Module]Module{mod(t, "b 1.0.0"): mod(t, "fork 1.0.0")}}
+			},
+			want: []string{"a 1.0.0", "b 1.0.0", "c 1.1.0"},
+		},
+		{name: "path replacement",
+			g: map[string][]string{
+				"a 1.0.0":    {"b 1.0.0"},
+				"b 1.0.0":    {"c 1.0.0"},
+				"fork 2.0.0": nil,
+				"c 1.0.0":    nil,
+			},
+			opts: func(t *testing.T) *Options {
+				return &Options{ReplacePath: map[string]Module{"b": mod(t, "fork 2.0.0")}}
+			},
+			want: []string{"a 1.0.0", "b 1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts *Options
+			if tt.opts != nil {
+				opts = tt.opts(t)
+			}
+			bl, err := Build(mod(t, "a 1.0.0"), newTestGraph(t, tt.g), opts)
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if got, want := bl.Modules(), mods(t, tt.want...); !reflect.DeepEqual(got, want) {
+				t.Errorf("Build() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBuildList_Why(t *testing.T) {
+	g := newTestGraph(t, blog)
+	bl, err := Build(mod(t, "a 1.0.0"), g, &Options{Exclude: mods(t, "e 1.2.0")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path   string
+		want   string
+		wantOk bool
+	}{
+		{path: "a", want: "a@1.0.0", wantOk: true},
+		{path: "b", want: "a@1.0.0 requires b@1.2.0", wantOk: true},
+		{path: "d", want: "a@1.0.0 requires c@1.2.0 requires d@1.4.0", wantOk: true},
+		{path: "e", want: "a@1.0.0 requires b@1.2.0 requires d@1.3.0 requires e@1.3.0 (e@1.2.0 is excluded)", wantOk: true},
+		{path: "f"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := bl.Why(tt.path)
+			if ok != tt.wantOk {
+				t.Fatalf("Why() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Why() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if v, ok := bl.Version("e"); !ok || v != (semver.SemVer{Major: 1, Minor: 3}) {
+		t.Errorf("Version() = %v, %v, want 1.3.0, true", v, ok)
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	tests := []struct {
+		name     string
+		upgrades []string
+		want     []string
+	}{
+		{name: "none",
+			want: []string{"a 1.0.0", "b 1.2.0", "c 1.2.0", "d 1.4.0", "e 1.2.0"},
+		},
+		{name: "upgrade c",
+			upgrades: []string{"c 1.3.0"},
+			want:     []string{"a 1.0.0", "b 1.2.0", "c 1.3.0", "d 1.4.0", "e 1.2.0", "f 1.1.0", "g 1.1.0"},
+		},
+		{name: "lower version has no effect",
+			upgrades: []string{"d 1.1.0"},
+			want:     []string{"a 1.0.0", "b 1.2.0", "c 1.2.0", "d 1.4.0", "e 1.2.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bl, err := Upgrade(mod(t, "a 1.0.0"), newTestGraph(t, blog), nil, mods(t, tt.upgrades...)...)
+			if err != nil {
+				t.Fatalf("Upgrade() error = %v", err)
+			}
+			if got, want := bl.Modules(), mods(t, tt.want...); !reflect.DeepEqual(got, want) {
+				t.Errorf("Upgrade() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestUpgradeAll(t *testing.T) {
+	g := newTestGraph(t, map[string][]string{
+		"a 1.0.0":      {"b 1.0.0"},
+		"b 1.0.0":      {"c 1.0.0"},
+		"b 1.1.0":      {"c 1.0.0"},
+		"b 1.2.0-rc.1": nil,
+		"c 1.0.0":      nil,
+		"c 1.1.0":      nil,
+		"c 1.2.0":      nil,
+	})
+	tests := []struct {
+		name string
+		opts func(t *testing.T) *Options
+		want []string
+	}{
+		{name: "latest release",
+			want: []string{"a 1.0.0", "b 1.1.0", "c 1.2.0"},
+		},
+		{name: "exclusion",
+			opts: func(t *testing.T) *Options { return &Options{Exclude: mods(t, "c 1.2.0")} },
+			want: []string{"a 1.0.0", "b 1.1.0", "c 1.1.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts *Options
+			if tt.opts != nil {
+				opts = tt.opts(t)
+			}
+			bl, err := UpgradeAll(mod(t, "a 1.0.0"), g, opts)
+			if err != nil {
+				t.Fatalf("UpgradeAll() error = %v", err)
+			}
+			if got, want := bl.Modules(), mods(t, tt.want...); !reflect.DeepEqual(got, want) {
+				t.Errorf("UpgradeAll() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		downgrades []string
+		want       []string
+		wantWhy    map[string]string
+	}{
+		{name: "downgrade d",
+			downgrades: []string{"d 1.2.0"},
+			want:       []string{"a 1.0.0", "b 1.1.0", "c 1.1.0", "d 1.2.0", "e 1.2.0"},
+			wantWhy: map[string]string{
+				"b": "a@1.0.0 requires b@1.1.0",
+				"d": "a@1.0.0 retains d@1.2.0",
+				"e": "a@1.0.0 retains e@1.2.0",
+			},
+		},
+		{name: "downgrade e",
+			downgrades: []string{"e 1.1.0"},
+			want:       []string{"a 1.0.0", "b 1.1.0", "c 1.1.0", "d 1.2.0", "e 1.1.0"},
+			wantWhy: map[string]string{
+				"c": "a@1.0.0 requires c@1.1.0",
+				"e": "a@1.0.0 requires b@1.1.0 requires d@1.1.0 requires e@1.1.0",
+			},
+		},
+		{name: "downgrade c",
+			downgrades: []string{"c 1.1.0"},
+			want:       []string{"a 1.0.0", "b 1.2.0", "c 1.1.0", "d 1.4.0", "e 1.2.0"},
+			wantWhy: map[string]string{
+				"e": "a@1.0.0 requires b@1.2.0 requires d@1.3.0 requires e@1.2.0",
+			},
+		},
+		{name: "remove b",
+			downgrades: []string{"b 1.0.0"},
+			want:       []string{"a 1.0.0", "c 1.2.0", "d 1.4.0", "e 1.2.0"},
+			wantWhy: map[string]string{
+				"d": "a@1.0.0 requires c@1.2.0 requires d@1.4.0",
+				"e": "a@1.0.0 requires c@1.2.0 requires d@1.4.0 requires e@1.2.0",
+			},
+		},
+		{name: "higher version has no effect",
+			downgrades: []string{"d 1.5.0"},
+			want:       []string{"a 1.0.0", "b 1.2.0", "c 1.2.0", "d 1.4.0", "e 1.2.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bl, err := Downgrade(mod(t, "a 1.0.0"), newTestGraph(t, blog), nil, mods(t, tt.downgrades...)...)
+			if err != nil {
+				t.Fatalf("Downgrade() error = %v", err)
+			}
+			if got, want := bl.Modules(), mods(t, tt.want...); !reflect.DeepEqual(got, want) {
+				t.Errorf("Downgrade() = %v, want %v", got, want)
+			}
+			for path, want := range tt.wantWhy {
+				if got, ok := bl.Why(path); !ok || got.String() != want {
+					t.Errorf("Why(%s) = %v, %v, want %v", path, got, ok, want)
+				}
+			}
+		})
+	}
+}
+
+type failingReqs struct {
+	Graph
+}
+
+func (*failingReqs) Versions(path string) ([]semver.SemVer, error) {
+	return nil, errors.New("proxy is down")
+}
+
+func TestBuild_Errors(t *testing.T) {
+	g := newTestGraph(t, map[string][]string{"a 1.0.0": {"b 1.0.0"}, "b 1.0.0": nil})
+	if _, err := Build(mod(t, "x 1.0.0"), g, nil); err == nil {
+		t.Errorf("Build() error = nil, want error for unknown target")
+	}
+	bad := &Graph{}
+	bad.Add(mod(t, "a 1.0.0"), Module{Path: "b", Version: semver.SemVer{Major: -1}})
+	if _, err := Build(mod(t, "a 1.0.0"), bad, nil); err == nil {
+		t.Errorf("Build() error = nil, want error for invalid version")
+	}
+	if _, err := Build(mod(t, "a 1.0.0"), g, &Options{Exclude: mods(t, "b 1.0.0")}); err == nil {
+		t.Errorf("Build() error = nil, want error for excluded version without successor")
+	}
+	f := &failingReqs{Graph: *g}
+	_, err := Build(mod(t, "a 1.0.0"), f, &Options{Exclude: mods(t, "b 1.0.0")})
+	if err == nil || err.Error() != "proxy is down" {
+		t.Errorf("Build() error = %v, want %v", err, "proxy is down")
+	}
+}
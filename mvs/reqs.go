@@ -0,0 +1,51 @@
+package mvs
+
+import (
+	"fmt"
+
+	"github.com/solsw/semver"
+)
+
+// Reqs is a requirement graph.
+type Reqs interface {
+	// Required returns the modules directly required by 'm'.
+	Required(m Module) ([]Module, error)
+	// Versions returns all available versions of the module 'path' in any order.
+	Versions(path string) ([]semver.SemVer, error)
+}
+
+// Graph is an in-memory [Reqs].
+// Graph's zero value is an empty graph ready to use.
+// Graph is not safe for concurrent use while modules are added.
+type Graph struct {
+	reqs map[Module][]Module
+}
+
+// Add adds module 'm' requiring 'reqs'.
+// Adding an existing module replaces its requirements.
+func (g *Graph) Add(m Module, reqs ...Module) {
+	if g.reqs == nil {
+		g.reqs = make(map[Module][]Module)
+	}
+	g.reqs[m] = reqs
+}
+
+// Required implements the [Reqs] interface.
+func (g *Graph) Required(m Module) ([]Module, error) {
+	rr, ok := g.reqs[m]
+	if !ok {
+		return nil, fmt.Errorf("unknown module %s", m)
+	}
+	return rr, nil
+}
+
+// Versions implements the [Reqs] interface.
+func (g *Graph) Versions(path string) ([]semver.SemVer, error) {
+	var vv []semver.SemVer
+	for m := range g.reqs {
+		if m.Path == path {
+			vv = append(vv, m.Version)
+		}
+	}
+	return vv, nil
+}
@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Object is a [SemVer] marshalled to JSON as an object instead of a string, e.g.
+//
+//	{"major": 1, "minor": 2, "patch": 3, "prerelease": "rc.1", "build": "b.5"}
+//
+// Empty pre-release version and build metadata are omitted. See [VersionObjectSchema].
+// Convert between the types with Object(v) and SemVer(o).
+type Object SemVer
+
+// object is the JSON form of [Object]; pointers detect missing version numbers.
+type object struct {
+	Major      *int64 `json:"major"`
+	Minor      *int64 `json:"minor"`
+	Patch      *int64 `json:"patch"`
+	PreRelease string `json:"prerelease,omitempty"`
+	Build      string `json:"build,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+// Like [SemVer.MarshalText], MarshalJSON does not validate 'o'.
+func (o Object) MarshalJSON() ([]byte, error) {
+	return json.Marshal(object{Major: &o.Major, Minor: &o.Minor, Patch: &o.Patch, PreRelease: o.PreRelease, Build: o.Build})
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+// UnmarshalJSON accepts a version object with all version numbers and no unknown fields,
+// or a version string, so data may be migrated from the text form.
+// Like encoding/json for other types, UnmarshalJSON leaves 'o' unchanged for JSON null.
+func (o *Object) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var v SemVer
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*o = Object(v)
+		return nil
+	}
+	var obj object
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&obj); err != nil {
+		return err
+	}
+	if obj.Major == nil || obj.Minor == nil || obj.Patch == nil {
+		return errors.New("malformed semver (missing version number)")
+	}
+	v = SemVer{Major: *obj.Major, Minor: *obj.Minor, Patch: *obj.Patch, PreRelease: obj.PreRelease, Build: obj.Build}
+	if err := Valid(v); err != nil {
+		return err
+	}
+	*o = Object(v)
+	return nil
+}
+
+// String implements the [fmt.Stringer] interface.
+func (o Object) String() string {
+	return SemVer(o).String()
+}
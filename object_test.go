@@ -0,0 +1,101 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestObject_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		v    string
+		want string
+	}{
+		{v: "1.2.3", want: `{"major":1,"minor":2,"patch":3}`},
+		{v: "0.0.0-rc.1+b.5", want: `{"major":0,"minor":0,"patch":0,"prerelease":"rc.1","build":"b.5"}`},
+		{v: "9223372036854775807.0.0+b", want: `{"major":9223372036854775807,"minor":0,"patch":0,"build":"b"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			o := Object(MustParse(tt.v))
+			b, err := json.Marshal(o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", b, tt.want)
+			}
+			var got Object
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got != o {
+				t.Errorf("UnmarshalJSON() = %v, want %v", got, o)
+			}
+		})
+	}
+}
+
+func TestObject_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{data: `{"major":1,"minor":2,"patch":3,"prerelease":"beta"}`, want: "1.2.3-beta"},
+		{data: `"1.2.3+b"`, want: "1.2.3+b"},
+		{data: `{"major":1,"minor":2}`, wantErr: true},
+		{data: `{"major":1,"minor":2,"patch":-3}`, wantErr: true},
+		{data: `{"major":1,"minor":2,"patch":3,"prerelease":"01"}`, wantErr: true},
+		{data: `{"major":1,"minor":2,"patch":3,"pre":"beta"}`, wantErr: true},
+		{data: `{"major":1.5,"minor":2,"patch":3}`, wantErr: true},
+		{data: `"1.2"`, wantErr: true},
+		{data: `[1,2,3]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var got Object
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && SemVer(got) != MustParse(tt.want) {
+				t.Errorf("UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObject_UnmarshalJSON_Null(t *testing.T) {
+	got := Object(MustParse("1.2.3"))
+	if err := json.Unmarshal([]byte(`null`), &got); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if SemVer(got) != MustParse("1.2.3") {
+		t.Errorf("UnmarshalJSON() = %v, want unchanged 1.2.3", got)
+	}
+	var r struct {
+		Version *Object `json:"version"`
+		Other   Object  `json:"other"`
+	}
+	if err := json.Unmarshal([]byte(`{"version":null,"other":null}`), &r); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if r.Version != nil || r.Other != (Object{}) {
+		t.Errorf("Unmarshal() = %+v, want zero", r)
+	}
+}
+
+func TestObject_Field(t *testing.T) {
+	type release struct {
+		Version Object `json:"version"`
+		Text    SemVer `json:"text"`
+	}
+	v := MustParse("2.0.0-rc.1")
+	b, err := json.Marshal(release{Version: Object(v), Text: v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"version":{"major":2,"minor":0,"patch":0,"prerelease":"rc.1"},"text":"2.0.0-rc.1"}`; got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
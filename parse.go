@@ -7,38 +7,51 @@ import (
 	"strings"
 )
 
+// versionParts holds the textual parts of a version string.
+type versionParts struct {
+	major, minor, patch string
+	preRelease, build   string
+}
+
 // Parse converts the version string to a [SemVer].
 func Parse(s string) (SemVer, error) {
-	ss := strings.SplitN(s, ".", 3)
-	if len(ss) < 3 {
-		return SemVer{}, errors.New("malformed semver")
+	p, err := splitVersion(s)
+	if err != nil {
+		return SemVer{}, err
 	}
-	var sv SemVer
-	var err error
-	if sv.Major, err = strToVersionNumber(ss[0]); err != nil {
+	sv := SemVer{PreRelease: p.preRelease, Build: p.build}
+	if sv.Major, err = strToVersionNumber(p.major); err != nil {
 		return SemVer{}, err
 	}
-	if sv.Minor, err = strToVersionNumber(ss[1]); err != nil {
+	if sv.Minor, err = strToVersionNumber(p.minor); err != nil {
 		return SemVer{}, err
 	}
-	if err = ss2ToPatchPreReleaseBuild(&sv, ss[2]); err != nil {
+	if sv.Patch, err = strToVersionNumber(p.patch); err != nil {
 		return SemVer{}, err
 	}
 	return sv, nil
 }
 
-func strToVersionNumber(s string) (int64, error) {
-	// https://semver.org/#spec-item-2: digits only, no sign, no leading zeros.
-	if !isNumeric(s) {
-		return 0, errors.New("malformed semver")
+// splitVersion splits the version string into its parts.
+// Pre-release version and build metadata are validated,
+// version numbers are left to the caller.
+func splitVersion(s string) (versionParts, error) {
+	ss := strings.SplitN(s, ".", 3)
+	if len(ss) < 3 {
+		return versionParts{}, errors.New("malformed semver")
 	}
-	if s == "0" {
-		return 0, nil
+	p := versionParts{major: ss[0], minor: ss[1]}
+	if err := ss2ToPatchPreReleaseBuild(&p, ss[2]); err != nil {
+		return versionParts{}, err
 	}
-	if strings.HasPrefix(s, "0") {
-		return 0, errors.New("malformed semver")
+	return p, nil
+}
+
+func strToVersionNumber(s string) (int64, error) {
+	if err := validVersionNumber(s); err != nil {
+		return 0, err
 	}
-	// isNumeric guarantees no sign, so any error here is an overflow.
+	// validVersionNumber guarantees no sign, so any error here is an overflow.
 	// ParseInt with bitSize 64 keeps parsing independent of the platform int width.
 	ver, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
@@ -47,52 +60,56 @@ func strToVersionNumber(s string) (int64, error) {
 	return ver, nil
 }
 
-func ss2ToPatchPreReleaseBuild(sv *SemVer, ss2 string) error {
+// validVersionNumber checks the textual form of a version number of any magnitude.
+func validVersionNumber(s string) error {
+	// https://semver.org/#spec-item-2: digits only, no sign, no leading zeros.
+	if !isNumeric(s) {
+		return errors.New("malformed semver")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return errors.New("malformed semver")
+	}
+	return nil
+}
+
+func ss2ToPatchPreReleaseBuild(p *versionParts, ss2 string) error {
 	extIdx := strings.IndexAny(ss2, "-+")
 	if extIdx == 0 {
 		// no Patch
 		return errors.New("malformed semver")
 	}
-	var err error
-	var patch string
 	if extIdx == -1 {
 		// no extension
-		patch = ss2
-	} else {
-		patch = ss2[:extIdx]
-		if err = extToPreReleaseBuild(sv, ss2[extIdx+1:], ss2[extIdx] == '+'); err != nil {
-			return err
-		}
+		p.patch = ss2
+		return nil
 	}
-	if sv.Patch, err = strToVersionNumber(patch); err != nil {
-		return err
-	}
-	return nil
+	p.patch = ss2[:extIdx]
+	return extToPreReleaseBuild(p, ss2[extIdx+1:], ss2[extIdx] == '+')
 }
 
-func extToPreReleaseBuild(sv *SemVer, ext string, noPreRelease bool) error {
+func extToPreReleaseBuild(p *versionParts, ext string, noPreRelease bool) error {
 	if len(ext) == 0 {
 		return errors.New("malformed semver")
 	}
 	if noPreRelease {
-		sv.Build = ext
+		p.build = ext
 	} else {
 		ee := strings.SplitN(ext, "+", 2)
 		if len(ee[0]) == 0 {
 			return errors.New("malformed semver")
 		}
-		sv.PreRelease = ee[0]
+		p.preRelease = ee[0]
 		if len(ee) > 1 {
 			if len(ee[1]) == 0 {
 				return errors.New("malformed semver")
 			}
-			sv.Build = ee[1]
+			p.build = ee[1]
 		}
 	}
-	if err := validExt(sv.PreRelease, true); err != nil {
+	if err := validExt(p.preRelease, true); err != nil {
 		return err
 	}
-	if err := validExt(sv.Build, false); err != nil {
+	if err := validExt(p.build, false); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,161 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Limits of [LimitError].
+const (
+	LimitLength           = "length"
+	LimitIdentifiers      = "identifiers"
+	LimitIdentifierLength = "identifier length"
+)
+
+// LimitError is returned by [Parser] when input exceeds a limit.
+type LimitError struct {
+	// Limit is one of the Limit constants.
+	Limit string
+	// Max is the limit, Actual is the (possibly partially counted) exceeding value.
+	Max, Actual int
+}
+
+// Error implements the error interface.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s %d exceeds limit %d", e.Limit, e.Actual, e.Max)
+}
+
+// Parser parses and validates versions with size limits,
+// so that hostile input (e.g. from HTTP headers or package manifests)
+// is rejected before it costs CPU and memory proportional to its size.
+// Limits are checked before any other parsing; zero means no limit.
+// Parser's zero value has no limits and works like [Parse] and [Valid].
+type Parser struct {
+	// MaxLength is the maximum length in bytes of a version or range string.
+	MaxLength int
+	// MaxIdentifiers is the maximum number of identifiers of a pre-release version,
+	// and, separately, of build metadata.
+	MaxIdentifiers int
+	// MaxIdentifierLength is the maximum length in bytes of an identifier.
+	MaxIdentifierLength int
+}
+
+// networkParser holds the limits returned by [NetworkParser].
+var networkParser = Parser{MaxLength: 256, MaxIdentifiers: 16, MaxIdentifierLength: 64}
+
+// NetworkParser returns a [Parser] with limits suitable for versions from untrusted network input.
+// Each call returns a new copy, so changing its limits does not affect other callers.
+func NetworkParser() Parser {
+	return networkParser
+}
+
+// checkLength checks 's' against p.MaxLength.
+func (p Parser) checkLength(s string) error {
+	if p.MaxLength > 0 && len(s) > p.MaxLength {
+		return &LimitError{Limit: LimitLength, Max: p.MaxLength, Actual: len(s)}
+	}
+	return nil
+}
+
+// checkExt checks the identifiers of pre-release version or build metadata 'ext'.
+// It stops at the first exceeded limit, so its cost is bounded by the limits.
+func (p Parser) checkExt(ext string) error {
+	if len(ext) == 0 {
+		return nil
+	}
+	n, l := 1, 0
+	for i := 0; i < len(ext); i++ {
+		if ext[i] == '.' {
+			n, l = n+1, 0
+			if p.MaxIdentifiers > 0 && n > p.MaxIdentifiers {
+				return &LimitError{Limit: LimitIdentifiers, Max: p.MaxIdentifiers, Actual: n}
+			}
+			continue
+		}
+		l++
+		if p.MaxIdentifierLength > 0 && l > p.MaxIdentifierLength {
+			return &LimitError{Limit: LimitIdentifierLength, Max: p.MaxIdentifierLength, Actual: l}
+		}
+	}
+	return nil
+}
+
+// numberLen returns the length of the decimal form of 'n'.
+func numberLen(n int64) int {
+	l := 1
+	if n < 0 {
+		l++
+	}
+	for n /= 10; n != 0; n /= 10 {
+		l++
+	}
+	return l
+}
+
+// checkVersion checks the pre-release version and build metadata of version string 's'.
+// The limits are checked on the raw extensions before they are split.
+func (p Parser) checkVersion(s string) error {
+	i := strings.IndexAny(s, "-+")
+	if i < 0 {
+		return nil
+	}
+	ext := s[i+1:]
+	if s[i] == '-' {
+		var build string
+		ext, build, _ = strings.Cut(ext, "+")
+		if err := p.checkExt(build); err != nil {
+			return err
+		}
+	}
+	return p.checkExt(ext)
+}
+
+// Parse is like [Parse], but returns a [*LimitError] if 's' exceeds the limits of 'p'.
+func (p Parser) Parse(s string) (SemVer, error) {
+	if err := p.checkLength(s); err != nil {
+		return SemVer{}, err
+	}
+	if err := p.checkVersion(s); err != nil {
+		return SemVer{}, err
+	}
+	return Parse(s)
+}
+
+// Valid is like [Valid], but returns a [*LimitError] if 'sv' exceeds the limits of 'p'.
+// The length limit applies to the [SemVer.String] form of 'sv'.
+func (p Parser) Valid(sv SemVer) error {
+	if p.MaxLength > 0 {
+		n := numberLen(sv.Major) + numberLen(sv.Minor) + numberLen(sv.Patch) + 2
+		for _, ext := range []string{sv.PreRelease, sv.Build} {
+			if len(ext) > 0 {
+				n += 1 + len(ext)
+			}
+		}
+		if n > p.MaxLength {
+			return &LimitError{Limit: LimitLength, Max: p.MaxLength, Actual: n}
+		}
+	}
+	if err := p.checkExt(sv.PreRelease); err != nil {
+		return err
+	}
+	if err := p.checkExt(sv.Build); err != nil {
+		return err
+	}
+	return Valid(sv)
+}
+
+// ParseRange is like [ParseRange], but returns a [*LimitError] if 's' is longer than p.MaxLength
+// or the version of a comparator exceeds the identifier limits of 'p'.
+func (p Parser) ParseRange(s string) (Range, error) {
+	if err := p.checkLength(s); err != nil {
+		return Range{}, err
+	}
+	// FieldsFuncSeq iterates over the comparators without allocating
+	for token := range strings.FieldsFuncSeq(s, func(r rune) bool { return unicode.IsSpace(r) || r == '|' }) {
+		if err := p.checkVersion(strings.TrimLeft(token, "<>=~^")); err != nil {
+			return Range{}, err
+		}
+	}
+	return ParseRange(s)
+}
@@ -0,0 +1,203 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParser_Parse(t *testing.T) {
+	p := Parser{MaxLength: 32, MaxIdentifiers: 3, MaxIdentifierLength: 5}
+	tests := []struct {
+		s         string
+		wantLimit string
+		wantErr   bool
+	}{
+		{s: "1.2.3"},
+		{s: "1.2.3-a.b.c+d.e.f"},
+		{s: "1.2.3-abcde+fghij"},
+		{s: "12345678901234567890.0.0", wantErr: true},
+		{s: "1.2.3-" + strings.Repeat("a", 26), wantLimit: LimitIdentifierLength},
+		{s: "1.2.3-" + strings.Repeat("a.", 20) + "a", wantLimit: LimitLength},
+		{s: "1.2.3-a.b.c.d", wantLimit: LimitIdentifiers},
+		{s: "1.2.3+a.b.c.d", wantLimit: LimitIdentifiers},
+		{s: "1.2.3-a+b.c.d.e", wantLimit: LimitIdentifiers},
+		{s: "1.2.3-abcdef", wantLimit: LimitIdentifierLength},
+		{s: "1.2.3+abcdef", wantLimit: LimitIdentifierLength},
+		{s: "1.2.3-a..b", wantErr: true},
+		{s: "1.2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := p.Parse(tt.s)
+			var le *LimitError
+			if errors.As(err, &le) {
+				if le.Limit != tt.wantLimit {
+					t.Fatalf("Parse() error = %v, want limit %q", err, tt.wantLimit)
+				}
+				return
+			}
+			if len(tt.wantLimit) > 0 {
+				t.Fatalf("Parse() error = %v, want limit %q", err, tt.wantLimit)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && fmt.Sprint(got) != tt.s {
+				t.Errorf("Parse() = %v, want %s", got, tt.s)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_boundaries(t *testing.T) {
+	p := Parser{MaxLength: 10, MaxIdentifiers: 2, MaxIdentifierLength: 2}
+	for _, s := range []string{"1.2.3-ab.c", "1.2.3+ab.c", "10.20.30-a"} {
+		if _, err := p.Parse(s); err != nil {
+			t.Errorf("Parse(%q) error = %v", s, err)
+		}
+	}
+	_, err := p.Parse("1.2.3-ab.cd")
+	var le *LimitError
+	if !errors.As(err, &le) || *le != (LimitError{Limit: LimitLength, Max: 10, Actual: 11}) {
+		t.Errorf("Parse() error = %#v", err)
+	}
+}
+
+func TestParser_zero(t *testing.T) {
+	var p Parser
+	for _, s := range append(readCorpus(t, "valid.txt"), readCorpus(t, "invalid.txt")...) {
+		got, err := p.Parse(s)
+		want, wantErr := Parse(s)
+		if (err != nil) != (wantErr != nil) || got != want {
+			t.Errorf("Parser{}.Parse(%q) = %v, %v, want %v, %v", s, got, err, want, wantErr)
+		}
+	}
+	long := "1.2.3-" + strings.Repeat("a.", 1000) + "a"
+	if _, err := p.Parse(long); err != nil {
+		t.Errorf("Parser{}.Parse() error = %v", err)
+	}
+}
+
+func TestParser_Valid(t *testing.T) {
+	p := Parser{MaxLength: 16, MaxIdentifiers: 2, MaxIdentifierLength: 4}
+	tests := []struct {
+		sv        SemVer
+		wantLimit string
+		wantErr   bool
+	}{
+		{sv: SemVer{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1"}},
+		{sv: SemVer{Major: 10, Minor: 20, Patch: 30, PreRelease: "a", Build: "b"}},
+		{sv: SemVer{Major: 1000000, Minor: 20, Patch: 30, PreRelease: "a", Build: "b"}, wantLimit: LimitLength},
+		{sv: SemVer{Major: 1, PreRelease: "a.b.c"}, wantLimit: LimitIdentifiers},
+		{sv: SemVer{Major: 1, Build: "abcde"}, wantLimit: LimitIdentifierLength},
+		{sv: SemVer{Major: -1}, wantErr: true},
+		{sv: SemVer{Major: 1, PreRelease: "01"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sv.String(), func(t *testing.T) {
+			err := p.Valid(tt.sv)
+			var le *LimitError
+			if errors.As(err, &le) != (len(tt.wantLimit) > 0) || (le != nil && le.Limit != tt.wantLimit) {
+				t.Fatalf("Valid() error = %v, want limit %q", err, tt.wantLimit)
+			}
+			if le == nil && (err != nil) != tt.wantErr {
+				t.Errorf("Valid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	np := NetworkParser()
+	for _, v := range strictVersions(500) {
+		if got, want := np.Valid(v) == nil, len(fmt.Sprint(v)) <= np.MaxLength; got != want {
+			t.Errorf("NetworkParser().Valid(%v) = %v", v, got)
+		}
+	}
+}
+
+func TestNetworkParser(t *testing.T) {
+	p := NetworkParser()
+	p.MaxLength = 1
+	if got := NetworkParser(); got.MaxLength != 256 {
+		t.Errorf("NetworkParser().MaxLength = %d, want 256", got.MaxLength)
+	}
+}
+
+func TestNumberLen(t *testing.T) {
+	for _, n := range []int64{0, 9, 10, 99, 100, -1, -10, 1<<63 - 1, -1 << 63} {
+		if got, want := numberLen(n), len(fmt.Sprint(n)); got != want {
+			t.Errorf("numberLen(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestParser_ParseRange(t *testing.T) {
+	p := Parser{MaxIdentifiers: 2, MaxIdentifierLength: 4}
+	tests := []struct {
+		s         string
+		wantLimit string
+		wantErr   bool
+	}{
+		{s: ">=1.2.3-rc.1 <2"},
+		{s: "^1.2.3-beta || 1.0.0 - 1.1.0-rc.2+b.1"},
+		{s: ">= 1.2.3-beta"},
+		{s: ">=1.2.3-" + strings.Repeat("a", 5), wantLimit: LimitIdentifierLength},
+		{s: "<2 || ~1.2.3-a.b.c", wantLimit: LimitIdentifiers},
+		{s: "1.0.0||1.0.0+a.b.c", wantLimit: LimitIdentifiers},
+		{s: "1.0.0 - 2.0.0-" + strings.Repeat("a.", 1<<10) + "a", wantLimit: LimitIdentifiers},
+		{s: "> = 1.2.3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			_, err := p.ParseRange(tt.s)
+			var le *LimitError
+			if errors.As(err, &le) != (len(tt.wantLimit) > 0) || (le != nil && le.Limit != tt.wantLimit) {
+				t.Fatalf("ParseRange() error = %v, want limit %q", err, tt.wantLimit)
+			}
+			if le == nil && (err != nil) != tt.wantErr {
+				t.Errorf("ParseRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	p = Parser{MaxLength: 12}
+	if _, err := p.ParseRange(">=1.2.3 <2"); err != nil {
+		t.Errorf("ParseRange() error = %v", err)
+	}
+	_, err := p.ParseRange(">=1.2.3 <2.0.0")
+	var le *LimitError
+	if !errors.As(err, &le) || le.Limit != LimitLength {
+		t.Errorf("ParseRange() error = %v", err)
+	}
+}
+
+// hostile is a version with a pre-release version of about 1MB.
+var hostile = "1.2.3-" + strings.Repeat("a.", 1<<19) + "a"
+
+func BenchmarkParse_hostile(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		Parse(hostile)
+	}
+}
+
+func BenchmarkParser_Parse_hostile(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		NetworkParser().Parse(hostile)
+	}
+}
+
+func BenchmarkParser_Parse(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		NetworkParser().Parse("1.22.333-rc.1.alpha-2+build.2026")
+	}
+}
+
+func BenchmarkCompare_longPreRelease(b *testing.B) {
+	v1, v2 := MustParse(hostile), MustParse(hostile+"b")
+	b.ReportAllocs()
+	for b.Loop() {
+		Compare(v1, v2)
+	}
+}
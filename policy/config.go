@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/solsw/semver"
+)
+
+// Rule names of [RuleConfig].
+const (
+	RuleMajorApproval    = "major-approval"
+	RulePreReleaseLabels = "pre-release-labels"
+	RuleBuildCommit      = "build-commit"
+	RuleNoSkip           = "no-skip"
+	RuleNoReRelease      = "no-re-release"
+)
+
+// Config is the declarative form of a [Policy], e.g.:
+//
+//	{"rules": [
+//		{"rule": "major-approval"},
+//		{"rule": "pre-release-labels", "labels": ["alpha", "beta", "rc"]},
+//		{"rule": "build-commit", "key": "git"},
+//		{"rule": "no-skip"},
+//		{"rule": "no-re-release"}
+//	]}
+type Config struct {
+	Rules []RuleConfig `json:"rules"`
+}
+
+// RuleConfig configures a rule.
+type RuleConfig struct {
+	// Name is one of the Rule constants.
+	Name string `json:"rule"`
+	// Labels are the labels of [PreReleaseLabels].
+	Labels []string `json:"labels,omitempty"`
+	// Key and Separator are the key and the separator of [semver.PairConvention] of [BuildCommit].
+	Key       string `json:"key,omitempty"`
+	Separator string `json:"separator,omitempty"`
+}
+
+// Rule returns the [Rule] configured by 'rc'.
+func (rc RuleConfig) Rule() (Rule, error) {
+	switch rc.Name {
+	case RuleMajorApproval:
+		return MajorApproval(), nil
+	case RulePreReleaseLabels:
+		if len(rc.Labels) == 0 {
+			return nil, fmt.Errorf("rule %q: no labels", rc.Name)
+		}
+		return PreReleaseLabels(rc.Labels...), nil
+	case RuleBuildCommit:
+		if len(rc.Key) == 0 {
+			return nil, fmt.Errorf("rule %q: no key", rc.Name)
+		}
+		return BuildCommit(rc.Key, semver.PairConvention{Separator: rc.Separator, Keys: []string{rc.Key}}), nil
+	case RuleNoSkip:
+		return NoSkip(), nil
+	case RuleNoReRelease:
+		return NoReRelease(), nil
+	}
+	return nil, fmt.Errorf("unknown rule %q", rc.Name)
+}
+
+// Policy returns the [Policy] configured by 'c'.
+func (c Config) Policy() (Policy, error) {
+	pol := make(Policy, 0, len(c.Rules))
+	for _, rc := range c.Rules {
+		r, err := rc.Rule()
+		if err != nil {
+			return nil, err
+		}
+		pol = append(pol, r)
+	}
+	return pol, nil
+}
+
+// ParseConfig decodes the JSON [Config] and returns the configured [Policy].
+// Unknown fields are errors.
+func ParseConfig(data []byte) (Policy, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var c Config
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("policy config: %w", err)
+	}
+	pol, err := c.Policy()
+	if err != nil {
+		return nil, fmt.Errorf("policy config: %w", err)
+	}
+	return pol, nil
+}
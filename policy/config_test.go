@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		version string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all rules",
+			config: `{"rules": [
+				{"rule": "major-approval"},
+				{"rule": "pre-release-labels", "labels": ["alpha", "beta", "rc"]},
+				{"rule": "build-commit", "key": "git"},
+				{"rule": "no-skip"},
+				{"rule": "no-re-release"}
+			]}`,
+			version: "3.0.0-preview+ci.1",
+			want:    []string{CodeMajorUnapproved, CodePreReleaseLabel, CodeMissingCommit, CodeSkippedVersion},
+		},
+		{name: "separator",
+			config:  `{"rules": [{"rule": "build-commit", "key": "git", "separator": "-"}]}`,
+			version: "1.2.0+ci.1.git-3a9f2c1",
+		},
+		{name: "empty", config: `{}`, version: "9.9.9"},
+		{name: "unknown rule", config: `{"rules": [{"rule": "no-fridays"}]}`, wantErr: true},
+		{name: "unknown field", config: `{"rules": [{"rule": "no-skip", "strict": true}]}`, wantErr: true},
+		{name: "no labels", config: `{"rules": [{"rule": "pre-release-labels"}]}`, wantErr: true},
+		{name: "no key", config: `{"rules": [{"rule": "build-commit"}]}`, wantErr: true},
+		{name: "malformed", config: `{"rules": `, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol, err := ParseConfig([]byte(tt.config))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			p := Proposal{Version: parse(t, tt.version)[0]}
+			if got := codes(pol.Check(p, parse(t, "1.0.0", "1.1.0"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package policy checks proposed releases against release policies.
+//
+// A [Rule] checks a [Proposal] against the history of existing versions
+// and returns the [Violation]s found; a [Policy] combines rules and returns all violations.
+// Policies can be declared in JSON (see [Config]).
+package policy
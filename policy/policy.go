@@ -0,0 +1,179 @@
+package policy
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/solsw/semver"
+)
+
+// Violation codes.
+const (
+	CodeInvalidVersion  = "invalid-version"
+	CodeMajorUnapproved = "major-unapproved"
+	CodePreReleaseLabel = "pre-release-label"
+	CodeMissingCommit   = "missing-commit"
+	CodeSkippedVersion  = "skipped-version"
+	CodeReRelease       = "re-release"
+)
+
+// Proposal is a proposed release.
+type Proposal struct {
+	Version semver.SemVer
+	// Approved reports whether the release is approved, e.g. by the release board.
+	Approved bool
+}
+
+// Violation is a policy violation.
+type Violation struct {
+	// Code is one of the Code constants or a code of a custom [Rule].
+	Code    string
+	Message string
+}
+
+// String implements the [fmt.Stringer] interface.
+func (v Violation) String() string {
+	return v.Code + ": " + v.Message
+}
+
+// Rule checks a proposed release against the history of existing versions.
+// 'p.Version' and the versions of 'history' are valid.
+type Rule interface {
+	Check(p Proposal, history []semver.SemVer) []Violation
+}
+
+// RuleFunc is an adapter to use a function as a [Rule].
+type RuleFunc func(p Proposal, history []semver.SemVer) []Violation
+
+// Check implements the [Rule] interface.
+func (f RuleFunc) Check(p Proposal, history []semver.SemVer) []Violation {
+	return f(p, history)
+}
+
+// Policy is a [Rule] combining rules.
+type Policy []Rule
+
+// Check implements the [Rule] interface.
+// Check returns the violations of all rules in order.
+// If 'p.Version' is invalid, Check returns only a [CodeInvalidVersion] violation;
+// invalid versions of 'history' are ignored.
+func (pol Policy) Check(p Proposal, history []semver.SemVer) []Violation {
+	if err := semver.Valid(p.Version); err != nil {
+		return []Violation{{Code: CodeInvalidVersion, Message: err.Error()}}
+	}
+	var valid []semver.SemVer
+	for _, v := range history {
+		if v.IsValid() {
+			valid = append(valid, v)
+		}
+	}
+	var vv []Violation
+	for _, r := range pol {
+		vv = append(vv, r.Check(p, valid)...)
+	}
+	return vv
+}
+
+func compareVersions(a, b semver.SemVer) int {
+	r, _ := semver.Compare(a, b)
+	return r
+}
+
+// MajorApproval returns a [Rule] reporting a [CodeMajorUnapproved] violation
+// if the proposal is not approved and its major version is higher than every major version of the history.
+// With an empty history, a proposal of major version 1 or higher is such a bump
+// (the first release of [initial development] needs no approval).
+//
+// [initial development]: https://semver.org/#spec-item-4
+func MajorApproval() Rule {
+	return RuleFunc(func(p Proposal, history []semver.SemVer) []Violation {
+		if p.Approved {
+			return nil
+		}
+		if len(history) == 0 {
+			if p.Version.Major == 0 {
+				return nil
+			}
+			return []Violation{{Code: CodeMajorUnapproved,
+				Message: fmt.Sprintf("first release of major version %d is not approved", p.Version.Major)}}
+		}
+		top := slices.MaxFunc(history, func(a, b semver.SemVer) int { return cmp.Compare(a.Major, b.Major) })
+		if p.Version.Major <= top.Major {
+			return nil
+		}
+		return []Violation{{Code: CodeMajorUnapproved,
+			Message: fmt.Sprintf("major version bump from %d to %d is not approved", top.Major, p.Version.Major)}}
+	})
+}
+
+// PreReleaseLabels returns a [Rule] reporting a [CodePreReleaseLabel] violation
+// if the pre-release version of the proposal does not start with one of 'labels'
+// as a separate identifier ("rc.1") or followed by digits ("rc1").
+func PreReleaseLabels(labels ...string) Rule {
+	return RuleFunc(func(p Proposal, _ []semver.SemVer) []Violation {
+		pr := p.Version.PreReleaseVersion()
+		if pr.Len() == 0 {
+			return nil
+		}
+		first := string(pr.Identifier(0))
+		label := strings.TrimRight(first, "0123456789")
+		if len(label) > 0 && slices.Contains(labels, label) {
+			return nil
+		}
+		return []Violation{{Code: CodePreReleaseLabel,
+			Message: fmt.Sprintf("pre-release label %q is not one of %s", first, strings.Join(labels, ", "))}}
+	})
+}
+
+// BuildCommit returns a [Rule] reporting a [CodeMissingCommit] violation
+// if the build metadata of the proposal has no commit hash under 'key' encoded according to 'c'
+// (see [semver.BuildMetadata.Commit]).
+func BuildCommit(key string, c semver.PairConvention) Rule {
+	return RuleFunc(func(p Proposal, _ []semver.SemVer) []Violation {
+		if _, err := p.Version.BuildMetadata().Commit(key, c); err != nil {
+			return []Violation{{Code: CodeMissingCommit,
+				Message: fmt.Sprintf("build metadata %q has no commit hash under %q: %v", p.Version.Build, key, err)}}
+		}
+		return nil
+	})
+}
+
+// NoSkip returns a [Rule] reporting a [CodeSkippedVersion] violation
+// if the version core of the proposal (its version without pre-release version and build metadata)
+// neither is the core of a version of the history nor directly follows a release of the history
+// (see [semver.IsSuccessor]). An empty history allows any version.
+func NoSkip() Rule {
+	return RuleFunc(func(p Proposal, history []semver.SemVer) []Violation {
+		if len(history) == 0 {
+			return nil
+		}
+		c := semver.SemVer{Major: p.Version.Major, Minor: p.Version.Minor, Patch: p.Version.Patch}
+		for _, h := range history {
+			if c == (semver.SemVer{Major: h.Major, Minor: h.Minor, Patch: h.Patch}) {
+				return nil
+			}
+			if len(h.PreRelease) == 0 && semver.IsSuccessor(h, p.Version) {
+				return nil
+			}
+		}
+		return []Violation{{Code: CodeSkippedVersion,
+			Message: fmt.Sprintf("%s does not directly follow any existing release", c)}}
+	})
+}
+
+// NoReRelease returns a [Rule] reporting a [CodeReRelease] violation
+// if the history contains a version of the same precedence as the proposal
+// (i.e. the same version, possibly with different build metadata).
+func NoReRelease() Rule {
+	return RuleFunc(func(p Proposal, history []semver.SemVer) []Violation {
+		for _, h := range history {
+			if compareVersions(h, p.Version) == 0 {
+				return []Violation{{Code: CodeReRelease,
+					Message: fmt.Sprintf("%s is already released as %s", p.Version, h)}}
+			}
+		}
+		return nil
+	})
+}
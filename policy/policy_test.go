@@ -0,0 +1,134 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/solsw/semver"
+)
+
+func parse(t *testing.T, ss ...string) []semver.SemVer {
+	t.Helper()
+	vv := make([]semver.SemVer, len(ss))
+	for i, s := range ss {
+		v, err := semver.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vv[i] = v
+	}
+	return vv
+}
+
+func codes(vv []Violation) []string {
+	var cc []string
+	for _, v := range vv {
+		cc = append(cc, v.Code)
+	}
+	return cc
+}
+
+func TestRules(t *testing.T) {
+	history := []string{"1.0.0", "1.1.0", "1.2.0-rc.1", "1.2.3", "2.0.0-beta.1"}
+	tests := []struct {
+		name     string
+		rule     Rule
+		version  string
+		approved bool
+		want     []string
+	}{
+		{name: "major approval minor bump", rule: MajorApproval(), version: "1.3.0"},
+		{name: "major approval major bump", rule: MajorApproval(), version: "3.0.0", want: []string{CodeMajorUnapproved}},
+		{name: "major approval approved", rule: MajorApproval(), version: "3.0.0", approved: true},
+		{name: "major approval pre-release line", rule: MajorApproval(), version: "2.0.0"},
+		{name: "labels release", rule: PreReleaseLabels("alpha", "beta", "rc"), version: "1.3.0"},
+		{name: "labels dotted", rule: PreReleaseLabels("alpha", "beta", "rc"), version: "1.3.0-rc.1"},
+		{name: "labels attached number", rule: PreReleaseLabels("alpha", "beta", "rc"), version: "1.3.0-beta2"},
+		{name: "labels other", rule: PreReleaseLabels("alpha", "beta", "rc"), version: "1.3.0-preview.1", want: []string{CodePreReleaseLabel}},
+		{name: "labels numeric", rule: PreReleaseLabels("alpha", "beta", "rc"), version: "1.3.0-1", want: []string{CodePreReleaseLabel}},
+		{name: "commit present", rule: BuildCommit("git", semver.PairConvention{}), version: "1.3.0+git.3a9f2c1"},
+		{name: "commit missing", rule: BuildCommit("git", semver.PairConvention{}), version: "1.3.0+ci.12", want: []string{CodeMissingCommit}},
+		{name: "commit malformed", rule: BuildCommit("git", semver.PairConvention{}), version: "1.3.0+git.xyz", want: []string{CodeMissingCommit}},
+		{name: "no skip patch", rule: NoSkip(), version: "1.2.4"},
+		{name: "no skip minor", rule: NoSkip(), version: "1.3.0-rc.1"},
+		{name: "no skip backport", rule: NoSkip(), version: "1.1.1"},
+		{name: "no skip major", rule: NoSkip(), version: "2.0.0"},
+		{name: "no skip final of pre-release", rule: NoSkip(), version: "1.2.0"},
+		{name: "skipped patch", rule: NoSkip(), version: "1.2.5", want: []string{CodeSkippedVersion}},
+		{name: "skipped minor", rule: NoSkip(), version: "1.4.0", want: []string{CodeSkippedVersion}},
+		{name: "skipped after pre-release only", rule: NoSkip(), version: "2.0.1", want: []string{CodeSkippedVersion}},
+		{name: "new version", rule: NoReRelease(), version: "1.2.4"},
+		{name: "re-release", rule: NoReRelease(), version: "1.2.3", want: []string{CodeReRelease}},
+		{name: "re-release with build", rule: NoReRelease(), version: "1.2.3+b.2", want: []string{CodeReRelease}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Proposal{Version: parse(t, tt.version)[0], Approved: tt.approved}
+			if got := codes(tt.rule.Check(p, parse(t, history...))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMajorApproval_FirstRelease(t *testing.T) {
+	tests := []struct {
+		version  string
+		approved bool
+		want     []string
+	}{
+		{version: "0.1.0"},
+		{version: "1.0.0", want: []string{CodeMajorUnapproved}},
+		{version: "5.0.0-rc.1", want: []string{CodeMajorUnapproved}},
+		{version: "1.0.0", approved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			p := Proposal{Version: parse(t, tt.version)[0], Approved: tt.approved}
+			if got := codes(MajorApproval().Check(p, nil)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	pol := Policy{MajorApproval(), PreReleaseLabels("rc"), BuildCommit("git", semver.PairConvention{}), NoSkip(), NoReRelease()}
+	history := append(parse(t, "1.0.0", "1.1.0"), semver.SemVer{Major: -1})
+	tests := []struct {
+		name    string
+		version semver.SemVer
+		want    []Violation
+	}{
+		{name: "valid",
+			version: parse(t, "1.2.0-rc.1+git.3a9f2c1")[0],
+		},
+		{name: "all violations",
+			version: parse(t, "3.0.0-beta+ci.1")[0],
+			want: []Violation{
+				{Code: CodeMajorUnapproved, Message: "major version bump from 1 to 3 is not approved"},
+				{Code: CodePreReleaseLabel, Message: `pre-release label "beta" is not one of rc`},
+				{Code: CodeMissingCommit, Message: `build metadata "ci.1" has no commit hash under "git": build metadata key not found`},
+				{Code: CodeSkippedVersion, Message: "3.0.0 does not directly follow any existing release"},
+			},
+		},
+		{name: "invalid",
+			version: semver.SemVer{Major: 1, PreRelease: "01"},
+			want:    []Violation{{Code: CodeInvalidVersion, Message: "malformed semver"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pol.Check(Proposal{Version: tt.version}, history); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViolation_String(t *testing.T) {
+	v := Violation{Code: CodeReRelease, Message: "1.0.0 is already released as 1.0.0"}
+	if got, want := v.String(), "re-release: 1.0.0 is already released as 1.0.0"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+}
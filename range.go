@@ -0,0 +1,470 @@
+package semver
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Range is a set of versions.
+// Range's [zero value] is the empty set; use [AnyRange] for the set of all versions.
+//
+// A Range is kept as a union of disjoint half-open intervals of versions ordered by [precedence],
+// so ranges support set operations ([Range.Intersect], [Range.Union], [Range.Complement]).
+// Unlike npm, pre-release versions are not treated specially: a range contains every version
+// whose precedence falls within it (e.g. "^1.2.3" contains "1.5.0-beta").
+// Build metadata is ignored.
+//
+// [zero value]: https://go.dev/ref/spec#The_zero_value
+// [precedence]: https://semver.org/#spec-item-11
+type Range struct {
+	ivs []interval
+}
+
+// interval is the half-open interval [lo, hi) of versions.
+// If hasHi is false, the interval has no upper bound.
+type interval struct {
+	lo    SemVer
+	hasHi bool
+	hi    SemVer
+}
+
+// minVersion is the version with the lowest precedence.
+var minVersion = SemVer{PreRelease: "0"}
+
+// successor returns the version immediately following 'v' in precedence.
+// The boolean result is false if the successor does not fit into int64.
+func successor(v SemVer) (SemVer, bool) {
+	if len(v.PreRelease) > 0 {
+		return SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch, PreRelease: v.PreRelease + ".0"}, true
+	}
+	return nextCore(v, 3)
+}
+
+// nextCore returns the lowest version above every version whose first 'n' (1-3) version numbers
+// are those of 'v'. A version number of math.MaxInt64 carries into the preceding one,
+// so e.g. "1.2.9223372036854775807" is followed by "1.3.0-0".
+// The boolean result is false if the major version overflows.
+func nextCore(v SemVer, n int) (SemVer, bool) {
+	if n >= 3 && v.Patch < math.MaxInt64 {
+		return SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1, PreRelease: "0"}, true
+	}
+	if n >= 2 && v.Minor < math.MaxInt64 {
+		return SemVer{Major: v.Major, Minor: v.Minor + 1, PreRelease: "0"}, true
+	}
+	if v.Major < math.MaxInt64 {
+		return SemVer{Major: v.Major + 1, PreRelease: "0"}, true
+	}
+	return SemVer{}, false
+}
+
+func (iv interval) empty() bool {
+	return iv.hasHi && compareValid(iv.lo, iv.hi) >= 0
+}
+
+func (iv interval) contains(v SemVer) bool {
+	return compareValid(iv.lo, v) <= 0 && (!iv.hasHi || compareValid(v, iv.hi) < 0)
+}
+
+// newRange returns the normalized union of 'ivs'.
+func newRange(ivs []interval) Range {
+	ivs = slices.DeleteFunc(ivs, interval.empty)
+	if len(ivs) == 0 {
+		return Range{}
+	}
+	slices.SortFunc(ivs, func(a, b interval) int {
+		return compareValid(a.lo, b.lo)
+	})
+	r := []interval{ivs[0]}
+	for _, iv := range ivs[1:] {
+		last := &r[len(r)-1]
+		if last.hasHi && compareValid(iv.lo, last.hi) > 0 {
+			r = append(r, iv)
+			continue
+		}
+		if !iv.hasHi || (last.hasHi && compareValid(iv.hi, last.hi) > 0) {
+			last.hasHi, last.hi = iv.hasHi, iv.hi
+		}
+	}
+	return Range{ivs: r}
+}
+
+// AnyRange returns the range containing all versions.
+func AnyRange() Range {
+	return Range{ivs: []interval{{lo: minVersion}}}
+}
+
+// ExactRange returns the range containing only 'v' (ignoring build metadata).
+// If 'v' is invalid, ExactRange returns the empty range.
+func ExactRange(v SemVer) Range {
+	if Valid(v) != nil {
+		return Range{}
+	}
+	v.Build = ""
+	hi, ok := successor(v)
+	return Range{ivs: []interval{{lo: v, hasHi: ok, hi: hi}}}
+}
+
+// Contains reports whether 'v' belongs to 'r'. An invalid 'v' belongs to no range.
+func (r Range) Contains(v SemVer) bool {
+	if Valid(v) != nil {
+		return false
+	}
+	for _, iv := range r.ivs {
+		if iv.contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty reports whether 'r' contains no versions.
+func (r Range) IsEmpty() bool {
+	return len(r.ivs) == 0
+}
+
+// IsAny reports whether 'r' contains all versions.
+func (r Range) IsAny() bool {
+	return len(r.ivs) == 1 && compareValid(r.ivs[0].lo, minVersion) == 0 && !r.ivs[0].hasHi
+}
+
+// Equal reports whether 'r' and 'other' contain the same versions.
+func (r Range) Equal(other Range) bool {
+	return slices.EqualFunc(r.ivs, other.ivs, func(a, b interval) bool {
+		return compareValid(a.lo, b.lo) == 0 && a.hasHi == b.hasHi && (!a.hasHi || compareValid(a.hi, b.hi) == 0)
+	})
+}
+
+// Union returns the range containing versions belonging to 'r' or 'other'.
+func (r Range) Union(other Range) Range {
+	return newRange(slices.Concat(r.ivs, other.ivs))
+}
+
+// Intersect returns the range containing versions belonging to both 'r' and 'other'.
+func (r Range) Intersect(other Range) Range {
+	var ivs []interval
+	for _, a := range r.ivs {
+		for _, b := range other.ivs {
+			iv := a
+			if compareValid(b.lo, iv.lo) > 0 {
+				iv.lo = b.lo
+			}
+			if b.hasHi && (!iv.hasHi || compareValid(b.hi, iv.hi) < 0) {
+				iv.hasHi, iv.hi = true, b.hi
+			}
+			ivs = append(ivs, iv)
+		}
+	}
+	return newRange(ivs)
+}
+
+// Complement returns the range containing versions not belonging to 'r'.
+func (r Range) Complement() Range {
+	var ivs []interval
+	lo, open := minVersion, true
+	for _, iv := range r.ivs {
+		ivs = append(ivs, interval{lo: lo, hasHi: true, hi: iv.lo})
+		if !iv.hasHi {
+			open = false
+			break
+		}
+		lo = iv.hi
+	}
+	if open {
+		ivs = append(ivs, interval{lo: lo})
+	}
+	return newRange(ivs)
+}
+
+// Difference returns the range containing versions belonging to 'r' but not to 'other'.
+func (r Range) Difference(other Range) Range {
+	return r.Intersect(other.Complement())
+}
+
+// IsSubsetOf reports whether every version of 'r' belongs to 'other'.
+func (r Range) IsSubsetOf(other Range) bool {
+	return r.Difference(other).IsEmpty()
+}
+
+// String implements the [fmt.Stringer] interface.
+// String returns the canonical form of 'r', which [ParseRange] converts back to an equal range.
+// The empty range is "<0.0.0-0".
+func (r Range) String() string {
+	if r.IsEmpty() {
+		return "<" + minVersion.String()
+	}
+	ss := make([]string, len(r.ivs))
+	for i, iv := range r.ivs {
+		ss[i] = iv.String()
+	}
+	return strings.Join(ss, " || ")
+}
+
+func (iv interval) String() string {
+	hasLo := compareValid(iv.lo, minVersion) != 0
+	if !hasLo && !iv.hasHi {
+		return "*"
+	}
+	if iv.hasHi && hasLo {
+		if succ, ok := successor(iv.lo); ok && compareValid(succ, iv.hi) == 0 {
+			return iv.lo.String()
+		}
+		if hi, ok := caretUpper(iv.lo, 3); ok && compareValid(hi, iv.hi) == 0 {
+			return "^" + iv.lo.String()
+		}
+		if hi, ok := tildeUpper(iv.lo, 3); ok && compareValid(hi, iv.hi) == 0 {
+			return "~" + iv.lo.String()
+		}
+	}
+	var ss []string
+	if hasLo {
+		if iv.lo.PreRelease == "0" && iv.lo.Patch > 0 {
+			ss = append(ss, ">"+SemVer{Major: iv.lo.Major, Minor: iv.lo.Minor, Patch: iv.lo.Patch - 1}.String())
+		} else {
+			ss = append(ss, ">="+iv.lo.String())
+		}
+	}
+	if iv.hasHi {
+		if iv.hi.PreRelease == "0" && iv.hi.Patch > 0 {
+			ss = append(ss, "<="+SemVer{Major: iv.hi.Major, Minor: iv.hi.Minor, Patch: iv.hi.Patch - 1}.String())
+		} else {
+			ss = append(ss, "<"+iv.hi.String())
+		}
+	}
+	return strings.Join(ss, " ")
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (r *Range) UnmarshalText(text []byte) error {
+	rr, err := ParseRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rr
+	return nil
+}
+
+// ParseRange converts the range string to a [Range].
+//
+// The syntax follows npm: a range is a list of comparator sets separated by "||",
+// a comparator set is a list of space-separated comparators that all must be satisfied.
+// A comparator is a version, optionally preceded by an operator:
+//
+//	1.2.3      exactly 1.2.3
+//	=1.2.3     exactly 1.2.3
+//	>1.2.3, >=1.2.3, <1.2.3, <=1.2.3
+//	~1.2.3     >=1.2.3 <1.3.0-0 (patch-level changes)
+//	^1.2.3     >=1.2.3 <2.0.0-0 (changes that do not modify the left-most non-zero number)
+//	1.2.3 - 2.3.4  >=1.2.3 <=2.3.4
+//
+// Versions may be partial or contain wildcards ('x', 'X' or '*') in place of numbers:
+// "1.2" and "1.2.x" mean ">=1.2.0 <1.3.0-0", "1" and "1.x" mean ">=1.0.0 <2.0.0-0",
+// "*" and the empty string mean any version; "<1.2" means "<1.2.0-0", ">1.2" means ">=1.3.0".
+// Only complete versions may have pre-release version and build metadata; build metadata is ignored.
+func ParseRange(s string) (Range, error) {
+	var ivs []interval
+	for _, alt := range strings.Split(s, "||") {
+		iv, err := parseComparatorSet(alt)
+		if err != nil {
+			return Range{}, err
+		}
+		ivs = append(ivs, iv)
+	}
+	return newRange(ivs), nil
+}
+
+// partial is a possibly incomplete version of a range comparator.
+type partial struct {
+	v SemVer
+	// n is the number of specified version numbers (0-3).
+	n int
+}
+
+func parsePartial(s string) (partial, error) {
+	if len(s) == 0 {
+		return partial{}, errors.New("malformed range")
+	}
+	ss := strings.SplitN(s, ".", 3)
+	if len(ss) == 3 && !isWildcard(ss[0]) && !isWildcard(ss[1]) {
+		p, err := splitVersion(s)
+		if err != nil {
+			return partial{}, err
+		}
+		if !isWildcard(p.patch) {
+			v, err := Parse(s)
+			if err != nil {
+				return partial{}, err
+			}
+			v.Build = ""
+			return partial{v: v, n: 3}, nil
+		}
+		if len(p.preRelease) > 0 || len(p.build) > 0 {
+			return partial{}, errors.New("malformed range")
+		}
+		ss[2] = p.patch
+	}
+	var p partial
+	nn := []*int64{&p.v.Major, &p.v.Minor, &p.v.Patch}
+	for i, part := range ss {
+		if isWildcard(part) {
+			for _, rest := range ss[i+1:] {
+				if !isWildcard(rest) {
+					return partial{}, errors.New("malformed range")
+				}
+			}
+			break
+		}
+		n, err := strToVersionNumber(part)
+		if err != nil {
+			return partial{}, err
+		}
+		*nn[i] = n
+		p.n++
+	}
+	return p, nil
+}
+
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+// nextUpper returns the lowest version above every version matching 'p'.
+// The boolean result is false if there is no such version.
+func (p partial) nextUpper() (SemVer, bool) {
+	switch p.n {
+	case 1, 2:
+		return nextCore(p.v, p.n)
+	case 3:
+		return successor(p.v)
+	}
+	return SemVer{}, false
+}
+
+// lower returns the lowest version matching 'p'.
+func (p partial) lower() SemVer {
+	if p.n == 0 {
+		return minVersion
+	}
+	return p.v
+}
+
+// tildeUpper returns the exclusive upper bound of "~v" where 'v' has 'n' version numbers specified.
+func tildeUpper(v SemVer, n int) (SemVer, bool) {
+	if n == 1 {
+		return partial{v: v, n: 1}.nextUpper()
+	}
+	return partial{v: v, n: 2}.nextUpper()
+}
+
+// caretUpper returns the exclusive upper bound of "^v" where 'v' has 'n' version numbers specified.
+func caretUpper(v SemVer, n int) (SemVer, bool) {
+	switch {
+	case v.Major > 0 || n == 1:
+		return partial{v: v, n: 1}.nextUpper()
+	case v.Minor > 0 || n == 2:
+		return partial{v: v, n: 2}.nextUpper()
+	}
+	return nextCore(SemVer{Patch: v.Patch}, 3)
+}
+
+var operators = []string{">=", "<=", ">", "<", "=", "~", "^"}
+
+func parseComparatorSet(s string) (interval, error) {
+	tokens := strings.Fields(s)
+	// join standalone operators with the following version
+	for i := 0; i < len(tokens)-1; i++ {
+		if slices.Contains(operators, tokens[i]) {
+			tokens[i] += tokens[i+1]
+			tokens = slices.Delete(tokens, i+1, i+2)
+		}
+	}
+	iv := interval{lo: minVersion}
+	if len(tokens) == 3 && tokens[1] == "-" {
+		lo, err := parsePartial(tokens[0])
+		if err != nil {
+			return interval{}, err
+		}
+		hi, err := parsePartial(tokens[2])
+		if err != nil {
+			return interval{}, err
+		}
+		iv.lo = lo.lower()
+		iv.hi, iv.hasHi = hi.nextUpper()
+		return iv, nil
+	}
+	for _, token := range tokens {
+		c, err := parseComparator(token)
+		if err != nil {
+			return interval{}, err
+		}
+		if compareValid(c.lo, iv.lo) > 0 {
+			iv.lo = c.lo
+		}
+		if c.hasHi && (!iv.hasHi || compareValid(c.hi, iv.hi) < 0) {
+			iv.hasHi, iv.hi = true, c.hi
+		}
+	}
+	return iv, nil
+}
+
+// emptyInterval contains no versions.
+var emptyInterval = interval{lo: minVersion, hasHi: true, hi: minVersion}
+
+func parseComparator(token string) (interval, error) {
+	op := ""
+	for _, o := range operators {
+		if strings.HasPrefix(token, o) {
+			op = o
+			break
+		}
+	}
+	p, err := parsePartial(token[len(op):])
+	if err != nil {
+		return interval{}, err
+	}
+	iv := interval{lo: minVersion}
+	switch op {
+	case "", "=":
+		iv.lo = p.lower()
+		iv.hi, iv.hasHi = p.nextUpper()
+	case ">=":
+		iv.lo = p.lower()
+	case ">":
+		lo, ok := p.nextUpper()
+		if !ok {
+			return emptyInterval, nil
+		}
+		if p.n < 3 {
+			// ">1.2" is ">=1.3.0", like in npm
+			lo.PreRelease = ""
+		}
+		iv.lo = lo
+	case "<":
+		if p.n == 0 {
+			return emptyInterval, nil
+		}
+		iv.hasHi, iv.hi = true, p.lower()
+		if p.n < 3 {
+			iv.hi.PreRelease = "0"
+		}
+	case "<=":
+		iv.hi, iv.hasHi = p.nextUpper()
+	case "~":
+		iv.lo = p.lower()
+		if p.n > 0 {
+			iv.hi, iv.hasHi = tildeUpper(p.v, p.n)
+		}
+	case "^":
+		iv.lo = p.lower()
+		if p.n > 0 {
+			iv.hi, iv.hasHi = caretUpper(p.v, p.n)
+		}
+	}
+	return iv, nil
+}
@@ -0,0 +1,232 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{name: "01", s: "1.2.3.4", wantErr: true},
+		{name: "02", s: ">=", wantErr: true},
+		{name: "03", s: "1.x.3", wantErr: true},
+		{name: "04", s: "1.2.x-beta", wantErr: true},
+		{name: "05", s: "01.2", wantErr: true},
+		{name: "06", s: "1.2 - ", wantErr: true},
+		{name: "07", s: "v1.2.3", wantErr: true},
+		{name: "08", s: "1.2.3-01", wantErr: true},
+		{name: "1", s: "", want: "*"},
+		{name: "2", s: "*", want: "*"},
+		{name: "3", s: "x.x.x", want: "*"},
+		{name: "4", s: "1.2.3", want: "1.2.3"},
+		{name: "5", s: "=1.2.3+build", want: "1.2.3"},
+		{name: "6", s: "1.2", want: "~1.2.0"},
+		{name: "7", s: "1.x", want: "^1.0.0"},
+		{name: "8", s: ">=1.2.3", want: ">=1.2.3"},
+		{name: "9", s: ">1.2.3", want: ">1.2.3"},
+		{name: "10", s: "<1.2.3", want: "<1.2.3"},
+		{name: "11", s: "<=1.2.3", want: "<=1.2.3"},
+		{name: "12", s: ">1.2", want: ">=1.3.0"},
+		{name: "13", s: "<1.2", want: "<1.2.0-0"},
+		{name: "14", s: "<=1.2", want: "<1.3.0-0"},
+		{name: "15", s: "~1.2.3", want: "~1.2.3"},
+		{name: "16", s: "~1", want: "^1.0.0"},
+		{name: "17", s: "^1.2.3", want: "^1.2.3"},
+		{name: "18", s: "^0.2.3", want: "^0.2.3"},
+		{name: "19", s: "^0.0.3", want: "0.0.3"},
+		{name: "20", s: "^0.0", want: "~0.0.0"},
+		{name: "21", s: "^0", want: ">=0.0.0 <1.0.0-0"},
+		{name: "22", s: "1.2.3 - 2.3.4", want: ">=1.2.3 <=2.3.4"},
+		{name: "23", s: "1.2 - 2.3", want: ">=1.2.0 <2.4.0-0"},
+		{name: "24", s: ">= 1.2 < 2", want: "^1.2.0"},
+		{name: "25", s: ">=1.2.7 <1.3.0 || 2.x", want: ">=1.2.7 <1.3.0 || ^2.0.0"},
+		{name: "26", s: "^1 || ^1.5", want: "^1.0.0"},
+		{name: "27", s: "<1.0.0 || >=1.0.0", want: "*"},
+		{name: "28", s: "<=1.2.3 || >1.2.3", want: "*"},
+		{name: "29", s: ">2 <1", want: "<0.0.0-0"},
+		{name: "30", s: "<0.0.0-0", want: "<0.0.0-0"},
+		{name: "31", s: ">*", want: "<0.0.0-0"},
+		{name: "32", s: "^1.2.3-beta.2", want: "^1.2.3-beta.2"},
+		{name: "33", s: ">1.2.3-rc", want: ">=1.2.3-rc.0"},
+		{name: "34", s: ">=9223372036854775807.0.0", want: ">=9223372036854775807.0.0"},
+		{name: "35", s: "^9223372036854775807.0.0", want: ">=9223372036854775807.0.0"},
+		{name: "36", s: "1.2.3 - *", want: ">=1.2.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRange(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseRange() = %v, want %v", got, tt.want)
+			}
+			back, err := ParseRange(got.String())
+			if err != nil || !back.Equal(got) {
+				t.Errorf("ParseRange(%q) = %v, %v, want %v", got.String(), back, err, got)
+			}
+		})
+	}
+}
+
+func TestRange_Contains(t *testing.T) {
+	tests := []struct {
+		name string
+		r    string
+		v    SemVer
+		want bool
+	}{
+		{name: "1", r: "^1.2.3", v: parseMust("1.2.3"), want: true},
+		{name: "2", r: "^1.2.3", v: parseMust("1.9.0"), want: true},
+		{name: "3", r: "^1.2.3", v: parseMust("2.0.0-alpha"), want: false},
+		{name: "4", r: "^1.2.3", v: parseMust("1.2.3-rc.1"), want: false},
+		{name: "5", r: "^1.2.3", v: parseMust("1.5.0-beta"), want: true},
+		{name: "6", r: "1.2.3", v: parseMust("1.2.3+build"), want: true},
+		{name: "7", r: "1.2.3", v: parseMust("1.2.4-0"), want: false},
+		{name: "8", r: "<2", v: parseMust("2.0.0-rc.1"), want: false},
+		{name: "9", r: "<2.0.0", v: parseMust("2.0.0-rc.1"), want: true},
+		{name: "10", r: "*", v: parseMust("0.0.0-0"), want: true},
+		{name: "11", r: "*", v: SemVer{Major: -1}, want: false},
+		{name: "12", r: "~1.2 || >=3", v: parseMust("2.0.0"), want: false},
+		{name: "13", r: "~1.2 || >=3", v: parseMust("3.1.0"), want: true},
+		{name: "14", r: ">1.2.3", v: parseMust("1.2.4-0"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRange(tt.r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.Contains(tt.v); got != tt.want {
+				t.Errorf("Range.Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_MaxInt64(t *testing.T) {
+	const max = "9223372036854775807"
+	tests := []struct {
+		r       string
+		in, out []string
+	}{
+		{r: "1.2." + max, in: []string{"1.2." + max, "1.2." + max + "+b"}, out: []string{"1.3.0-0", "2.0.0"}},
+		{r: "<=1.2." + max, in: []string{"1.2." + max}, out: []string{"1.3.0-0", "5.0.0"}},
+		{r: ">1.2." + max, in: []string{"1.3.0-0", "1.3.0"}, out: []string{"1.2." + max}},
+		{r: "1." + max, in: []string{"1." + max + ".5"}, out: []string{"2.0.0-0", "5.0.0"}},
+		{r: "1." + max + ".x", in: []string{"1." + max + "." + max}, out: []string{"2.0.0-0"}},
+		{r: "~1." + max + ".3", in: []string{"1." + max + ".4"}, out: []string{"2.0.0-0"}},
+		{r: "^0.0." + max, in: []string{"0.0." + max}, out: []string{"0.1.0-0"}},
+		{r: "^0." + max, in: []string{"0." + max + ".1"}, out: []string{"1.0.0-0"}},
+		{r: max, in: []string{max + "." + max + "." + max}, out: []string{"1.0.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.r, func(t *testing.T) {
+			r, err := ParseRange(tt.r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.in {
+				if !r.Contains(MustParse(s)) {
+					t.Errorf("%v does not contain %s", r, s)
+				}
+			}
+			for _, s := range tt.out {
+				if r.Contains(MustParse(s)) {
+					t.Errorf("%v contains %s", r, s)
+				}
+			}
+			if r2, err := ParseRange(r.String()); err != nil || !r2.Equal(r) {
+				t.Errorf("ParseRange(%q) = %v, %v, want %v", r.String(), r2, err, r)
+			}
+		})
+	}
+	v := MustParse("1.2." + max)
+	r := ExactRange(v)
+	if r.String() != v.String() || r.Contains(MustParse("2.0.0")) || !r.Contains(v) {
+		t.Errorf("ExactRange(%v) = %v", v, r)
+	}
+}
+
+func parseRangeMust(s string) Range {
+	r, _ := ParseRange(s)
+	return r
+}
+
+func TestRange_SetOperations(t *testing.T) {
+	tests := []struct {
+		name       string
+		r1, r2     string
+		union      string
+		intersect  string
+		difference string
+	}{
+		{name: "1", r1: "^1.0.0", r2: "^2.0.0",
+			union: "^1.0.0 || ^2.0.0", intersect: "<0.0.0-0", difference: "^1.0.0"},
+		{name: "2", r1: "^1.0.0", r2: ">=1.5.0",
+			union: ">=1.0.0", intersect: "^1.5.0", difference: ">=1.0.0 <1.5.0"},
+		{name: "3", r1: "*", r2: "1.2.3",
+			union: "*", intersect: "1.2.3", difference: "<1.2.3 || >1.2.3"},
+		{name: "4", r1: "<0.0.0-0", r2: "~1.2",
+			union: "~1.2.0", intersect: "<0.0.0-0", difference: "<0.0.0-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r1, r2 := parseRangeMust(tt.r1), parseRangeMust(tt.r2)
+			if got := r1.Union(r2).String(); got != tt.union {
+				t.Errorf("Range.Union() = %v, want %v", got, tt.union)
+			}
+			if got := r1.Intersect(r2).String(); got != tt.intersect {
+				t.Errorf("Range.Intersect() = %v, want %v", got, tt.intersect)
+			}
+			if got := r1.Difference(r2).String(); got != tt.difference {
+				t.Errorf("Range.Difference() = %v, want %v", got, tt.difference)
+			}
+			if !r1.Complement().Complement().Equal(r1) {
+				t.Errorf("Range.Complement().Complement() = %v, want %v", r1.Complement().Complement(), r1)
+			}
+			if !r1.Intersect(r2).IsSubsetOf(r1) || !r1.IsSubsetOf(r1.Union(r2)) {
+				t.Errorf("Range.IsSubsetOf() = false, want true")
+			}
+		})
+	}
+}
+
+func TestRange_Predicates(t *testing.T) {
+	var zero Range
+	if !zero.IsEmpty() || zero.IsAny() || zero.Contains(SemVer{}) {
+		t.Errorf("zero Range is not empty")
+	}
+	if !AnyRange().IsAny() || AnyRange().IsEmpty() || !AnyRange().Complement().IsEmpty() {
+		t.Errorf("AnyRange() is not any")
+	}
+	r := ExactRange(parseMust("1.2.3-rc.1+b"))
+	if r.String() != "1.2.3-rc.1" || !r.Contains(parseMust("1.2.3-rc.1")) || r.Contains(parseMust("1.2.3-rc.1.0")) {
+		t.Errorf("ExactRange() = %v", r)
+	}
+	if !ExactRange(SemVer{Major: -1}).IsEmpty() {
+		t.Errorf("ExactRange() of invalid version is not empty")
+	}
+}
+
+func TestRange_UnmarshalText(t *testing.T) {
+	var r Range
+	if err := r.UnmarshalText([]byte(">=1.2 <2")); err != nil {
+		t.Fatal(err)
+	}
+	text, _ := r.MarshalText()
+	if string(text) != "^1.2.0" {
+		t.Errorf("Range.MarshalText() = %s, want %s", text, "^1.2.0")
+	}
+	if err := r.UnmarshalText([]byte("1.2.3.4")); err == nil {
+		t.Errorf("Range.UnmarshalText() error = nil, want error")
+	}
+}
@@ -0,0 +1,8 @@
+// Package registry keeps the versions and supported version ranges of named components
+// (e.g. the current and the minimum supported versions of client platforms) that change at runtime.
+//
+// A [Registry] is safe for concurrent use. Updates are atomic, may be conditional on the
+// revision of an entry ([Registry.CompareAndSwap]) and reject version downgrades unless forced.
+// Changes are reported to subscribers ([Registry.Subscribe], [Registry.Watch]).
+// The registry is snapshotted to and restored from JSON with [Registry.MarshalJSON] and [Registry.UnmarshalJSON].
+package registry
@@ -0,0 +1,269 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/solsw/semver"
+)
+
+// Entry is the state of a component.
+type Entry struct {
+	// Version is the current version of the component.
+	Version semver.SemVer `json:"version"`
+	// Range is the range of supported versions of the component
+	// (the zero value is the empty range).
+	Range semver.Range `json:"range"`
+	// Revision is the revision of the registry at the last change of the entry.
+	// It is set by the [Registry]; zero means that the component does not exist.
+	Revision uint64 `json:"revision"`
+}
+
+// Change describes a change of an entry.
+// Old.Revision is zero if the component has been added,
+// New.Revision is zero if the component has been deleted.
+type Change struct {
+	Name string
+	Old  Entry
+	New  Entry
+}
+
+// ConflictError is returned by [Registry.CompareAndSwap]
+// if the entry has changed since the expected revision.
+type ConflictError struct {
+	Name string
+	// Expected is the revision passed to [Registry.CompareAndSwap].
+	Expected uint64
+	// Actual is the current revision of the entry.
+	Actual uint64
+}
+
+// Error implements the error interface.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: revision %d expected, %d found", e.Name, e.Expected, e.Actual)
+}
+
+// DowngradeError is returned by updates that are not forced
+// if the new version is lower than the current one.
+type DowngradeError struct {
+	Name string
+	From semver.SemVer
+	To   semver.SemVer
+}
+
+// Error implements the error interface.
+func (e *DowngradeError) Error() string {
+	return fmt.Sprintf("%s: downgrade from %v to %v", e.Name, e.From, e.To)
+}
+
+// Registry holds [Entry]s keyed by component name.
+// Registry's zero value is an empty registry ready to use.
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	entries  map[string]Entry
+	revision uint64
+	subs     map[int]func(Change)
+	nextSub  int
+	// delivered is closed when the subscribers have been called for the last change,
+	// so that notifications keep the order of changes without holding mu
+	delivered chan struct{}
+}
+
+// Get returns the entry of component 'name'.
+// The boolean result is false if there is no such component.
+func (r *Registry) Get(name string) (Entry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.entries[name]
+	return e, ok
+}
+
+// Names returns the sorted names of the components.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.entries))
+}
+
+// Set sets the version and the range of component 'name' to those of 'e'.
+// Unless 'force' is true, Set returns a [*DowngradeError]
+// if the version of 'e' is lower than the current version.
+func (r *Registry) Set(name string, e Entry, force bool) error {
+	return r.update(name, e, force, func(Entry) error { return nil })
+}
+
+// CompareAndSwap is like [Registry.Set], but only sets the entry if its revision is 'revision',
+// returning a [*ConflictError] otherwise. Zero 'revision' means that the component must not exist.
+func (r *Registry) CompareAndSwap(name string, revision uint64, e Entry, force bool) error {
+	return r.update(name, e, force, func(old Entry) error {
+		if old.Revision != revision {
+			return &ConflictError{Name: name, Expected: revision, Actual: old.Revision}
+		}
+		return nil
+	})
+}
+
+func (r *Registry) update(name string, e Entry, force bool, check func(old Entry) error) error {
+	if err := semver.Valid(e.Version); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	r.mu.Lock()
+	old := r.entries[name]
+	if err := check(old); err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	if !force && old.Revision > 0 && semver.Less(e.Version, old.Version) {
+		r.mu.Unlock()
+		return &DowngradeError{Name: name, From: old.Version, To: e.Version}
+	}
+	if r.entries == nil {
+		r.entries = make(map[string]Entry)
+	}
+	r.revision++
+	e.Revision = r.revision
+	r.entries[name] = e
+	r.notify(Change{Name: name, Old: old, New: e})
+	return nil
+}
+
+// Delete deletes component 'name'.
+// The boolean result is false if there is no such component.
+func (r *Registry) Delete(name string) bool {
+	r.mu.Lock()
+	old, ok := r.entries[name]
+	if !ok {
+		r.mu.Unlock()
+		return false
+	}
+	delete(r.entries, name)
+	r.revision++
+	r.notify(Change{Name: name, Old: old})
+	return true
+}
+
+// notify unlocks 'r.mu' locked by the caller and calls the subscribers
+// after they have been called for the previous changes.
+func (r *Registry) notify(cc ...Change) {
+	subs := make([]func(Change), 0, len(r.subs))
+	for _, id := range slices.Sorted(maps.Keys(r.subs)) {
+		subs = append(subs, r.subs[id])
+	}
+	prev, done := r.delivered, make(chan struct{})
+	r.delivered = done
+	r.mu.Unlock()
+	defer close(done)
+	if prev != nil {
+		<-prev
+	}
+	for _, c := range cc {
+		for _, f := range subs {
+			f(c)
+		}
+	}
+}
+
+// Subscribe registers 'f' to be called after each change until 'cancel' is called.
+// Subscribers are called sequentially in the order of changes and of subscription;
+// they may read the registry, but must not modify it.
+func (r *Registry) Subscribe(f func(Change)) (cancel func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.subs == nil {
+		r.subs = make(map[int]func(Change))
+	}
+	id := r.nextSub
+	r.nextSub++
+	r.subs[id] = f
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.subs, id)
+	}
+}
+
+// Watch returns a channel receiving changes until 'ctx' is done; then the channel is closed.
+// The channel has buffer of size 'n'; when it is full, changes block until received.
+func (r *Registry) Watch(ctx context.Context, n int) <-chan Change {
+	ch := make(chan Change, n)
+	var mu sync.Mutex
+	closed := false
+	cancel := r.Subscribe(func(c Change) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- c:
+		case <-ctx.Done():
+		}
+	})
+	go func() {
+		<-ctx.Done()
+		cancel()
+		mu.Lock()
+		defer mu.Unlock()
+		closed = true
+		close(ch)
+	}()
+	return ch
+}
+
+// snapshot is the JSON form of a [Registry].
+type snapshot struct {
+	Revision uint64           `json:"revision"`
+	Entries  map[string]Entry `json:"entries"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+// Versions and ranges are marshalled in their text form.
+func (r *Registry) MarshalJSON() ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s := snapshot{Revision: r.revision, Entries: r.entries}
+	if s.Entries == nil {
+		s.Entries = map[string]Entry{}
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+// UnmarshalJSON restores the registry from a snapshot made by [Registry.MarshalJSON]
+// replacing all entries (downgrades included) and reports the differences to subscribers.
+func (r *Registry) UnmarshalJSON(data []byte) error {
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	for name, e := range s.Entries {
+		if err := semver.Valid(e.Version); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if e.Revision == 0 || e.Revision > s.Revision {
+			return fmt.Errorf("%s: revision %d is out of range", name, e.Revision)
+		}
+	}
+	r.mu.Lock()
+	var cc []Change
+	for _, name := range slices.Sorted(maps.Keys(r.entries)) {
+		if _, ok := s.Entries[name]; !ok {
+			cc = append(cc, Change{Name: name, Old: r.entries[name]})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.Entries)) {
+		old, e := r.entries[name], s.Entries[name]
+		if old.Revision != e.Revision || old.Version != e.Version || !old.Range.Equal(e.Range) {
+			cc = append(cc, Change{Name: name, Old: old, New: e})
+		}
+	}
+	r.entries = s.Entries
+	r.revision = max(r.revision, s.Revision)
+	r.notify(cc...)
+	return nil
+}
@@ -0,0 +1,279 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/solsw/semver"
+)
+
+func mustParse(t *testing.T, s string) semver.SemVer {
+	t.Helper()
+	v, err := semver.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func mustParseRange(t *testing.T, s string) semver.Range {
+	t.Helper()
+	r, err := semver.ParseRange(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRegistry_Set(t *testing.T) {
+	var r Registry
+	if err := r.Set("ios", Entry{Version: mustParse(t, "2.3.0"), Range: mustParseRange(t, ">=2.0.0")}, false); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		version string
+		force   bool
+		want    string
+		wantErr string
+	}{
+		{name: "upgrade", version: "2.4.0", want: "2.4.0"},
+		{name: "same precedence", version: "2.4.0+b", want: "2.4.0+b"},
+		{name: "downgrade", version: "2.4.0-rc.1", want: "2.4.0+b", wantErr: "ios: downgrade from 2.4.0+b to 2.4.0-rc.1"},
+		{name: "forced downgrade", version: "2.3.1", force: true, want: "2.3.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Set("ios", Entry{Version: mustParse(t, tt.version)}, tt.force)
+			if err != nil && err.Error() != tt.wantErr || err == nil && tt.wantErr != "" {
+				t.Fatalf("Set() error = %v, want %v", err, tt.wantErr)
+			}
+			var de *DowngradeError
+			if tt.wantErr != "" && !errors.As(err, &de) {
+				t.Errorf("Set() error is not *DowngradeError")
+			}
+			e, _ := r.Get("ios")
+			if got := fmt.Sprintf("%v", e.Version); got != tt.want {
+				t.Errorf("Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if err := r.Set("ios", Entry{Version: semver.SemVer{Major: -1}}, true); err == nil {
+		t.Errorf("Set() of invalid version error = nil")
+	}
+}
+
+func TestRegistry_CompareAndSwap(t *testing.T) {
+	var r Registry
+	v1, v2 := mustParse(t, "1.0.0"), mustParse(t, "1.1.0")
+	if err := r.CompareAndSwap("android", 0, Entry{Version: v1}, false); err != nil {
+		t.Fatal(err)
+	}
+	err := r.CompareAndSwap("android", 0, Entry{Version: v2}, false)
+	var ce *ConflictError
+	if !errors.As(err, &ce) || ce.Expected != 0 || ce.Actual != 1 {
+		t.Fatalf("CompareAndSwap() error = %v, want conflict", err)
+	}
+	e, _ := r.Get("android")
+	if err := r.CompareAndSwap("android", e.Revision, Entry{Version: v2}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CompareAndSwap("android", e.Revision, Entry{Version: v2}, false); err == nil {
+		t.Errorf("CompareAndSwap() with stale revision error = nil")
+	}
+	e, _ = r.Get("android")
+	if e.Version != v2 || e.Revision != 2 {
+		t.Errorf("Get() = %+v", e)
+	}
+}
+
+func TestRegistry_CompareAndSwap_Concurrent(t *testing.T) {
+	var r Registry
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				for {
+					e, _ := r.Get("desktop")
+					e.Version.Patch++
+					err := r.CompareAndSwap("desktop", e.Revision, e, false)
+					if err == nil {
+						break
+					}
+					var ce *ConflictError
+					if !errors.As(err, &ce) {
+						t.Error(err)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if e, _ := r.Get("desktop"); e.Version.Patch != 800 {
+		t.Errorf("Get() = %v, want 0.0.800", e.Version)
+	}
+}
+
+func TestRegistry_Subscribe(t *testing.T) {
+	var r Registry
+	var got []string
+	cancel := r.Subscribe(func(c Change) {
+		// subscribers may read the registry
+		e, ok := r.Get(c.Name)
+		got = append(got, fmt.Sprintf("%s %v(%d)->%v(%d) %v", c.Name, c.Old.Version, c.Old.Revision, c.New.Version, c.New.Revision, ok && e.Revision == c.New.Revision || !ok))
+	})
+	r.Set("ios", Entry{Version: mustParse(t, "1.0.0")}, false)
+	r.Set("ios", Entry{Version: mustParse(t, "0.9.0")}, false)
+	r.Set("ios", Entry{Version: mustParse(t, "1.1.0")}, false)
+	r.Delete("ios")
+	r.Delete("ios")
+	cancel()
+	r.Set("ios", Entry{Version: mustParse(t, "2.0.0")}, false)
+	want := []string{
+		"ios 0.0.0(0)->1.0.0(1) true",
+		"ios 1.0.0(1)->1.1.0(2) true",
+		"ios 1.1.0(2)->0.0.0(0) true",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("changes = %q, want %q", got, want)
+	}
+}
+
+func TestRegistry_Subscribe_Concurrent(t *testing.T) {
+	var r Registry
+	var last uint64
+	ordered := true
+	r.Subscribe(func(c Change) {
+		// reading while another writer waits to notify must not deadlock;
+		// the sleep lets the other writer start waiting
+		time.Sleep(10 * time.Microsecond)
+		r.Get(c.Name)
+		r.Names()
+		ordered = ordered && c.New.Revision > last
+		last = c.New.Revision
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for _, name := range []string{"ios", "android"} {
+			wg.Go(func() {
+				for i := range 100 {
+					r.Set(name, Entry{Version: semver.SemVer{Major: int64(i)}}, false)
+				}
+			})
+		}
+		wg.Wait()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("concurrent Set() with reading subscriber deadlocked")
+	}
+	if !ordered || last != 200 {
+		t.Errorf("changes are not notified in order of revisions, last = %d", last)
+	}
+}
+
+func TestRegistry_Watch_Full(t *testing.T) {
+	var r Registry
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.Watch(ctx, 0)
+	go r.Set("web", Entry{Version: mustParse(t, "1.0.0")}, false)
+	go r.Set("ios", Entry{Version: mustParse(t, "1.0.0")}, false)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// the unread channel blocks the notifications, but neither the other writer nor readers
+		for len(r.Names()) < 2 {
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a full Watch channel blocks readers")
+	}
+}
+
+func TestRegistry_Watch(t *testing.T) {
+	var r Registry
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := r.Watch(ctx, 1)
+	go func() {
+		for i := range 3 {
+			r.Set("web", Entry{Version: semver.SemVer{Major: int64(i)}}, false)
+		}
+	}()
+	for i := range 3 {
+		c := <-ch
+		if c.New.Version.Major != int64(i) {
+			t.Errorf("change %d = %v", i, c.New.Version)
+		}
+	}
+	cancel()
+	for range ch {
+	}
+	// a cancelled watch does not block updates
+	if err := r.Set("web", Entry{Version: semver.SemVer{Major: 5}}, false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegistry_JSON(t *testing.T) {
+	var r Registry
+	r.Set("ios", Entry{Version: mustParse(t, "2.3.0+b.7"), Range: mustParseRange(t, ">=2.0.0")}, false)
+	r.Set("android", Entry{Version: mustParse(t, "1.0.0-rc.1")}, false)
+	b, err := json.Marshal(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"revision":2,"entries":{"android":{"version":"1.0.0-rc.1","range":"\u003c0.0.0-0","revision":2},` +
+		`"ios":{"version":"2.3.0+b.7","range":"\u003e=2.0.0","revision":1}}}`
+	if string(b) != want {
+		t.Errorf("Marshal() = %s, want %s", b, want)
+	}
+
+	var restored Registry
+	restored.Set("web", Entry{Version: mustParse(t, "3.0.0")}, false)
+	restored.Set("ios", Entry{Version: mustParse(t, "9.0.0")}, false)
+	var changes []string
+	restored.Subscribe(func(c Change) {
+		changes = append(changes, fmt.Sprintf("%s %v->%v", c.Name, c.Old.Version, c.New.Version))
+	})
+	if err := json.Unmarshal(b, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(changes), "[web 3.0.0->0.0.0 android 0.0.0->1.0.0-rc.1 ios 9.0.0->2.3.0+b.7]"; got != want {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+	b2, _ := json.Marshal(&restored)
+	if string(b2) != string(b) {
+		t.Errorf("Marshal() after Unmarshal() = %s, want %s", b2, b)
+	}
+	if e, _ := restored.Get("ios"); !e.Range.Contains(mustParse(t, "2.0.0")) {
+		t.Errorf("restored range = %v", e.Range)
+	}
+
+	for _, s := range []string{
+		`{"revision":1,"entries":{"ios":{"version":"1.x","revision":1}}}`,
+		`{"revision":1,"entries":{"ios":{"version":"1.0.0","revision":2}}}`,
+		`{"revision":1,"entries":{"ios":{"version":"1.0.0","range":"^^1","revision":1}}}`,
+	} {
+		if err := json.Unmarshal([]byte(s), &restored); err == nil {
+			t.Errorf("Unmarshal(%s) error = nil", s)
+		}
+	}
+	if got := restored.Names(); fmt.Sprint(got) != "[android ios]" {
+		t.Errorf("Names() after failed Unmarshal() = %v", got)
+	}
+}
@@ -0,0 +1,10 @@
+// Package resolver implements dependency resolution over [semver.SemVer] versions
+// with the [PubGrub] algorithm.
+//
+// Packages are identified by name, their available versions and dependencies
+// (given as [semver.Range] constraints) are provided by a [Source].
+// When no solution exists, [Resolve] returns a [*NoSolutionError]
+// whose message explains the conflict in human-readable form.
+//
+// [PubGrub]: https://github.com/dart-lang/pub/blob/master/doc/solver.md
+package resolver
@@ -0,0 +1,118 @@
+package resolver
+
+import (
+	"strings"
+
+	"github.com/solsw/semver"
+)
+
+// causeKind is the reason an incompatibility exists.
+type causeKind int
+
+const (
+	// causeRoot: the root package must be selected.
+	causeRoot causeKind = iota
+	// causeNoVersions: no available version of a package is in the range.
+	causeNoVersions
+	// causeDependency: versions of a package depend on a range of another package.
+	causeDependency
+	// causeDerived: the incompatibility is derived from cause1 and cause2 during conflict resolution.
+	causeDerived
+)
+
+// incompatibility is a set of terms that must not all be satisfied.
+type incompatibility struct {
+	// terms hold at most one term per package.
+	terms          []term
+	kind           causeKind
+	cause1, cause2 *incompatibility
+}
+
+// newDerived returns the incompatibility derived from 'cause1' and 'cause2' with 'terms'.
+// Terms of the same package are merged, and positive terms of the root package are dropped
+// unless they are the only terms, since the root is always selected.
+func newDerived(terms []term, root string, cause1, cause2 *incompatibility) *incompatibility {
+	var merged []term
+	idx := make(map[string]int)
+	for _, t := range terms {
+		if i, ok := idx[t.pkg]; ok {
+			merged[i] = merged[i].intersect(t)
+			continue
+		}
+		idx[t.pkg] = len(merged)
+		merged = append(merged, t)
+	}
+	if len(merged) != 1 {
+		filtered := merged[:0]
+		for _, t := range merged {
+			if !(t.positive && t.pkg == root) {
+				filtered = append(filtered, t)
+			}
+		}
+		merged = filtered
+	}
+	return &incompatibility{terms: merged, kind: causeDerived, cause1: cause1, cause2: cause2}
+}
+
+// get returns the term of 'pkg', if any.
+func (inc *incompatibility) get(pkg string) (term, bool) {
+	for _, t := range inc.terms {
+		if t.pkg == pkg {
+			return t, true
+		}
+	}
+	return term{}, false
+}
+
+// isFailure reports whether 'inc' means that resolution failed.
+func (inc *incompatibility) isFailure(root string) bool {
+	return len(inc.terms) == 0 || (len(inc.terms) == 1 && inc.terms[0].positive && inc.terms[0].pkg == root)
+}
+
+// external describes an incompatibility that is not derived.
+func (inc *incompatibility) external() string {
+	switch inc.kind {
+	case causeRoot:
+		return "we are resolving " + pkgRange(inc.terms[0].pkg, inc.terms[0].r)
+	case causeNoVersions:
+		t := inc.terms[0]
+		if t.r.IsAny() {
+			return "there is no version of " + t.pkg
+		}
+		return "there is no version of " + t.pkg + " in " + t.r.String()
+	case causeDependency:
+		return pkgRange(inc.terms[0].pkg, inc.terms[0].r) + " depends on " + pkgRange(inc.terms[1].pkg, inc.terms[1].r)
+	}
+	return inc.conclusion("")
+}
+
+// conclusion describes what 'inc' states.
+func (inc *incompatibility) conclusion(root string) string {
+	if inc.isFailure(root) {
+		return "version solving failed"
+	}
+	tt := inc.terms
+	switch {
+	case len(tt) == 1 && tt[0].positive:
+		return pkgRange(tt[0].pkg, tt[0].r) + " is forbidden"
+	case len(tt) == 1:
+		return pkgRange(tt[0].pkg, tt[0].r) + " is required"
+	case len(tt) == 2 && tt[0].positive && !tt[1].positive:
+		return pkgRange(tt[0].pkg, tt[0].r) + " depends on " + pkgRange(tt[1].pkg, tt[1].r)
+	case len(tt) == 2 && !tt[0].positive && tt[1].positive:
+		return pkgRange(tt[1].pkg, tt[1].r) + " depends on " + pkgRange(tt[0].pkg, tt[0].r)
+	}
+	ss := make([]string, len(tt))
+	for i, t := range tt {
+		ss[i] = t.String()
+	}
+	return strings.Join(ss[:len(ss)-1], ", ") + " and " + ss[len(ss)-1] + " are incompatible"
+}
+
+// dependencyIncompatibility returns the incompatibility "versions 'r' of 'pkg' depend on 'dr' of 'dep'".
+func dependencyIncompatibility(pkg string, r semver.Range, dep string, dr semver.Range) *incompatibility {
+	return &incompatibility{
+		terms: []term{{pkg: pkg, positive: true, r: r}, {pkg: dep, r: dr}},
+		kind:  causeDependency,
+	}
+}
@@ -0,0 +1,122 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reporter builds the explanation of a failed resolution
+// from the derivation graph of the failure incompatibility.
+type reporter struct {
+	root  string
+	lines []string
+	// refs holds line numbers of already explained incompatibilities.
+	refs map[*incompatibility]int
+	// shared holds derived incompatibilities referenced more than once.
+	shared map[*incompatibility]bool
+}
+
+func explain(inc *incompatibility, root string) string {
+	if inc.kind != causeDerived {
+		return "Because " + inc.external() + ", " + inc.conclusion(root) + "."
+	}
+	r := &reporter{
+		root:   root,
+		refs:   make(map[*incompatibility]int),
+		shared: make(map[*incompatibility]bool),
+	}
+	r.markShared(inc, make(map[*incompatibility]bool))
+	r.build(inc)
+	return strings.Join(r.lines, "\n")
+}
+
+func (r *reporter) markShared(inc *incompatibility, seen map[*incompatibility]bool) {
+	if inc.kind != causeDerived {
+		return
+	}
+	if seen[inc] {
+		r.shared[inc] = true
+		return
+	}
+	seen[inc] = true
+	r.markShared(inc.cause1, seen)
+	r.markShared(inc.cause2, seen)
+}
+
+func (r *reporter) add(format string, a ...any) {
+	r.lines = append(r.lines, fmt.Sprintf(format, a...))
+}
+
+// addRef numbers the last line as the explanation of 'inc'.
+func (r *reporter) addRef(inc *incompatibility) {
+	n := len(r.refs) + 1
+	r.refs[inc] = n
+	r.lines[len(r.lines)-1] += fmt.Sprintf(" (%d)", n)
+}
+
+func (r *reporter) build(inc *incompatibility) {
+	r.buildLines(inc)
+	if _, ok := r.refs[inc]; r.shared[inc] && !ok {
+		r.addRef(inc)
+	}
+}
+
+func (r *reporter) buildLines(cur *incompatibility) {
+	c1, c2 := cur.cause1, cur.cause2
+	concl := cur.conclusion(r.root)
+	switch d1, d2 := c1.kind == causeDerived, c2.kind == causeDerived; {
+	case !d1 && !d2:
+		r.add("Because %s and %s, %s.", c1.external(), c2.external(), concl)
+	case d1 && !d2:
+		r.buildOneEach(c1, c2, cur)
+	case !d1 && d2:
+		r.buildOneEach(c2, c1, cur)
+	default:
+		ref1, ok1 := r.refs[c1]
+		ref2, ok2 := r.refs[c2]
+		switch {
+		case ok1 && ok2:
+			r.add("Because %s (%d) and %s (%d), %s.", c1.conclusion(r.root), ref1, c2.conclusion(r.root), ref2, concl)
+		case ok1:
+			r.build(c2)
+			r.add("And because %s (%d), %s.", c1.conclusion(r.root), ref1, concl)
+		case ok2:
+			r.build(c1)
+			r.add("And because %s (%d), %s.", c2.conclusion(r.root), ref2, concl)
+		default:
+			r.build(c1)
+			if r.shared[c1] {
+				// c1 got a line number, explain 'cur' referring to it
+				r.add("")
+				r.buildLines(cur)
+				return
+			}
+			r.addRef(c1)
+			r.add("")
+			r.build(c2)
+			r.add("And because %s (%d), %s.", c1.conclusion(r.root), r.refs[c1], concl)
+		}
+	}
+}
+
+// buildOneEach explains 'cur' derived from 'derived' and 'external'.
+func (r *reporter) buildOneEach(derived, external, cur *incompatibility) {
+	concl := cur.conclusion(r.root)
+	if ref, ok := r.refs[derived]; ok {
+		r.add("Because %s (%d) and %s, %s.", derived.conclusion(r.root), ref, external.external(), concl)
+		return
+	}
+	// if 'derived' has an external cause, chain both external causes in one line
+	p1, p2 := derived.cause1, derived.cause2
+	switch {
+	case p1.kind == causeDerived && p2.kind != causeDerived:
+		r.build(p1)
+		r.add("And because %s and %s, %s.", p2.external(), external.external(), concl)
+	case p1.kind != causeDerived && p2.kind == causeDerived:
+		r.build(p2)
+		r.add("And because %s and %s, %s.", p1.external(), external.external(), concl)
+	default:
+		r.build(derived)
+		r.add("And because %s, %s.", external.external(), concl)
+	}
+}
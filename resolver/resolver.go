@@ -0,0 +1,382 @@
+package resolver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/solsw/semver"
+)
+
+// NoSolutionError is returned by [Resolve] when no set of versions satisfies all constraints.
+type NoSolutionError struct {
+	inc  *incompatibility
+	root string
+}
+
+// Error implements the error interface.
+// Error returns a multi-line explanation of the conflict, e.g.:
+//
+//	Because a ^1.0.0 depends on b ^2.0.0 and c depends on b <2.0.0, a ^1.0.0 and c are incompatible.
+//	And because root depends on a ^1.0.0 and c, version solving failed.
+func (e *NoSolutionError) Error() string {
+	return explain(e.inc, e.root)
+}
+
+// Resolve selects a version of every package reachable from version 'version' of package 'root'
+// such that all dependencies are satisfied.
+// For each package the highest matching version is preferred;
+// pre-release versions are selected only if no matching release version is available.
+// Dependencies of 'root' are obtained from 'src'; 'version' need not be among the versions 'src' lists.
+//
+// Resolve returns the selected versions keyed by package name (including 'root'),
+// a [*NoSolutionError] if no solution exists or the error returned by 'src'.
+func Resolve(src Source, root string, version semver.SemVer) (map[string]semver.SemVer, error) {
+	if err := semver.Valid(version); err != nil {
+		return nil, err
+	}
+	s := &solver{
+		src:         src,
+		root:        root,
+		rootVersion: version,
+		ps:          newPartialSolution(),
+		incompats:   make(map[string][]*incompatibility),
+		versions:    make(map[string][]semver.SemVer),
+		deps:        make(map[string]map[string]semver.Range),
+		listed:      make(map[string][]*incompatibility),
+	}
+	s.addIncompatibility(&incompatibility{
+		terms: []term{{pkg: root, r: semver.ExactRange(version)}},
+		kind:  causeRoot,
+	})
+	next := root
+	for {
+		if err := s.propagate(next); err != nil {
+			return nil, err
+		}
+		pkg, err := s.choose()
+		if err != nil {
+			return nil, err
+		}
+		if pkg == "" {
+			return maps.Clone(s.ps.decisions), nil
+		}
+		next = pkg
+	}
+}
+
+type solver struct {
+	src         Source
+	root        string
+	rootVersion semver.SemVer
+	ps          *partialSolution
+	// incompats holds incompatibilities by package of their terms.
+	incompats map[string][]*incompatibility
+	// versions caches valid available versions in ascending order.
+	versions map[string][]semver.SemVer
+	// deps caches dependencies by package and version.
+	deps map[string]map[string]semver.Range
+	// listed holds dependency incompatibilities already added, by package and version.
+	listed map[string][]*incompatibility
+}
+
+func (s *solver) addIncompatibility(inc *incompatibility) {
+	for _, t := range inc.terms {
+		s.incompats[t.pkg] = append(s.incompats[t.pkg], inc)
+	}
+}
+
+func compareVersions(a, b semver.SemVer) int {
+	r, _ := semver.Compare(a, b)
+	return r
+}
+
+func (s *solver) availableVersions(pkg string) ([]semver.SemVer, error) {
+	if vv, ok := s.versions[pkg]; ok {
+		return vv, nil
+	}
+	var vv []semver.SemVer
+	if pkg == s.root {
+		vv = []semver.SemVer{s.rootVersion}
+	} else {
+		all, err := s.src.Versions(pkg)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range all {
+			if v.IsValid() {
+				vv = append(vv, v)
+			}
+		}
+		slices.SortFunc(vv, compareVersions)
+	}
+	s.versions[pkg] = vv
+	return vv, nil
+}
+
+func (s *solver) dependencies(pkg string, v semver.SemVer) (map[string]semver.Range, error) {
+	key := pkg + "@" + v.String()
+	if deps, ok := s.deps[key]; ok {
+		return deps, nil
+	}
+	deps, err := s.src.Dependencies(pkg, v)
+	if err != nil {
+		return nil, err
+	}
+	s.deps[key] = deps
+	return deps, nil
+}
+
+// propagate performs unit propagation starting with the incompatibilities of 'pkg'.
+func (s *solver) propagate(pkg string) error {
+	changed := []string{pkg}
+	for len(changed) > 0 {
+		pkg, changed = changed[0], changed[1:]
+		incs := s.incompats[pkg]
+	loop:
+		for i := len(incs) - 1; i >= 0; i-- {
+			rel, t := s.ps.relation(incs[i])
+			switch rel {
+			case satisfied:
+				cause, err := s.resolveConflict(incs[i])
+				if err != nil {
+					return err
+				}
+				// backjumping erased the assignments the conflict was based on,
+				// so 'cause' is now almost satisfied
+				_, t = s.ps.relation(cause)
+				s.ps.derive(t.negate(), cause)
+				changed = []string{t.pkg}
+				break loop
+			case almostSatisfied:
+				s.ps.derive(t.negate(), incs[i])
+				if !slices.Contains(changed, t.pkg) {
+					changed = append(changed, t.pkg)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveConflict derives from satisfied 'inc' the root cause of the conflict,
+// backjumps to the decision level where the root cause becomes almost satisfied and returns it.
+func (s *solver) resolveConflict(inc *incompatibility) (*incompatibility, error) {
+	derived := false
+	for {
+		if inc.isFailure(s.root) {
+			return nil, &NoSolutionError{inc: inc, root: s.root}
+		}
+		var mostRecentTerm term
+		var difference *term
+		mostRecent := -1
+		previousLevel := 0
+		for _, t := range inc.terms {
+			idx := s.ps.satisfier(t)
+			switch {
+			case mostRecent < 0:
+				mostRecent, mostRecentTerm = idx, t
+			case mostRecent < idx:
+				previousLevel = max(previousLevel, s.ps.assignments[mostRecent].level)
+				mostRecent, mostRecentTerm = idx, t
+				difference = nil
+			default:
+				previousLevel = max(previousLevel, s.ps.assignments[idx].level)
+				continue
+			}
+			// the satisfier may satisfy the term only together with earlier assignments,
+			// which then determine the previous level too
+			diff := s.ps.assignments[mostRecent].term.intersect(t.negate())
+			if !diff.isEmpty() {
+				difference = &diff
+				previousLevel = max(previousLevel, s.ps.assignments[s.ps.satisfier(diff.negate())].level)
+			}
+		}
+		satisfier := s.ps.assignments[mostRecent]
+		if satisfier.decision || previousLevel != satisfier.level {
+			if derived {
+				s.addIncompatibility(inc)
+			}
+			s.ps.backtrack(previousLevel)
+			return inc, nil
+		}
+		var terms []term
+		for _, t := range inc.terms {
+			if t.pkg != mostRecentTerm.pkg {
+				terms = append(terms, t)
+			}
+		}
+		for _, t := range satisfier.cause.terms {
+			if t.pkg != satisfier.pkg {
+				terms = append(terms, t)
+			}
+		}
+		if difference != nil {
+			terms = append(terms, difference.negate())
+		}
+		inc = newDerived(terms, s.root, inc, satisfier.cause)
+		derived = true
+	}
+}
+
+// allowed returns the available versions of 'pkg' matching the partial solution.
+func (s *solver) allowed(pkg string) ([]semver.SemVer, error) {
+	vv, err := s.availableVersions(pkg)
+	if err != nil {
+		return nil, err
+	}
+	t := s.ps.accumulated[pkg]
+	var r []semver.SemVer
+	for _, v := range vv {
+		if t.r.Contains(v) {
+			r = append(r, v)
+		}
+	}
+	return r, nil
+}
+
+// choose selects a version of a package that is required but not yet decided
+// and returns the package, or the empty string if every required package is decided.
+func (s *solver) choose() (string, error) {
+	var candidates []string
+	for pkg, t := range s.ps.accumulated {
+		if _, ok := s.ps.decisions[pkg]; t.positive && !ok {
+			candidates = append(candidates, pkg)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	// the package with the fewest matching versions is the most likely to conflict
+	slices.Sort(candidates)
+	var pkg string
+	var allowed []semver.SemVer
+	for _, c := range candidates {
+		vv, err := s.allowed(c)
+		if err != nil {
+			return "", err
+		}
+		if len(pkg) == 0 || len(vv) < len(allowed) {
+			pkg, allowed = c, vv
+		}
+	}
+	if len(allowed) == 0 {
+		s.addIncompatibility(&incompatibility{
+			terms: []term{{pkg: pkg, positive: true, r: s.ps.accumulated[pkg].r}},
+			kind:  causeNoVersions,
+		})
+		return pkg, nil
+	}
+	v := preferred(allowed)
+	incs, err := s.dependencyIncompatibilities(pkg, v)
+	if err != nil {
+		return "", err
+	}
+	conflict := false
+	for _, inc := range incs {
+		// if an incompatibility is already satisfied, selecting 'v' causes a conflict,
+		// unit propagation will lead to a better version
+		all := true
+		for _, t := range inc.terms {
+			if t.pkg != pkg && s.ps.termRelation(t) != satisfied {
+				all = false
+				break
+			}
+		}
+		conflict = conflict || all
+	}
+	if !conflict {
+		s.ps.decide(pkg, v)
+	}
+	return pkg, nil
+}
+
+// preferred returns the highest release version of ascending 'vv',
+// or the highest version if all of 'vv' are pre-release versions.
+func preferred(vv []semver.SemVer) semver.SemVer {
+	for i := len(vv) - 1; i >= 0; i-- {
+		if len(vv[i].PreRelease) == 0 {
+			return vv[i]
+		}
+	}
+	return vv[len(vv)-1]
+}
+
+// dependencyIncompatibilities adds (once) and returns the incompatibilities
+// for the dependencies of version 'v' of 'pkg'.
+// Each incompatibility covers the widest run of adjacent available versions
+// with the same dependency, to make explanations concise.
+func (s *solver) dependencyIncompatibilities(pkg string, v semver.SemVer) ([]*incompatibility, error) {
+	key := pkg + "@" + v.String()
+	if incs, ok := s.listed[key]; ok {
+		return incs, nil
+	}
+	deps, err := s.dependencies(pkg, v)
+	if err != nil {
+		return nil, err
+	}
+	vv, err := s.availableVersions(pkg)
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(vv, func(w semver.SemVer) bool { return compareVersions(v, w) == 0 })
+	var incs []*incompatibility
+	for _, dep := range slices.Sorted(maps.Keys(deps)) {
+		if dep == pkg {
+			continue
+		}
+		same := func(w semver.SemVer) (bool, error) {
+			wdeps, err := s.dependencies(pkg, w)
+			if err != nil {
+				return false, err
+			}
+			r, ok := wdeps[dep]
+			return ok && r.Equal(deps[dep]), nil
+		}
+		lo, hi := idx, idx
+		for ; lo > 0; lo-- {
+			ok, err := same(vv[lo-1])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+		}
+		for ; hi < len(vv)-1; hi++ {
+			ok, err := same(vv[hi+1])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+		}
+		r, err := versionsRange(vv, lo, hi)
+		if err != nil {
+			return nil, err
+		}
+		inc := dependencyIncompatibility(pkg, r, dep, deps[dep])
+		s.addIncompatibility(inc)
+		incs = append(incs, inc)
+	}
+	s.listed[key] = incs
+	return incs, nil
+}
+
+// versionsRange returns the range covering ascending 'vv[lo:hi+1]' and no other of 'vv',
+// unbounded at the ends of 'vv'.
+func versionsRange(vv []semver.SemVer, lo, hi int) (semver.Range, error) {
+	var s string
+	if lo > 0 {
+		s = ">=" + vv[lo].String()
+	}
+	if hi < len(vv)-1 {
+		s += " <" + vv[hi+1].String()
+	}
+	r, err := semver.ParseRange(s)
+	if err != nil {
+		return semver.Range{}, fmt.Errorf("resolver: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,241 @@
+package resolver
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/solsw/semver"
+)
+
+// registry builds a MemorySource from "pkg version" keys and dependency maps.
+func registry(t *testing.T, pkgs map[string]map[string]string) *MemorySource {
+	t.Helper()
+	var src MemorySource
+	for key, deps := range pkgs {
+		pkg, version, _ := strings.Cut(key, " ")
+		v, err := semver.Parse(version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := make(map[string]semver.Range, len(deps))
+		for dep, constraint := range deps {
+			if rr[dep], err = semver.ParseRange(constraint); err != nil {
+				t.Fatal(err)
+			}
+		}
+		src.Add(pkg, v, rr)
+	}
+	return &src
+}
+
+func versions(t *testing.T, m map[string]string) map[string]semver.SemVer {
+	t.Helper()
+	r := make(map[string]semver.SemVer, len(m))
+	for pkg, version := range m {
+		v, err := semver.Parse(version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r[pkg] = v
+	}
+	return r
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name string
+		pkgs map[string]map[string]string
+		want map[string]string
+	}{
+		{name: "no dependencies",
+			pkgs: map[string]map[string]string{"root 1.0.0": nil},
+			want: map[string]string{"root": "1.0.0"},
+		},
+		{name: "no conflicts",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0": {"foo": "^1.0.0"},
+				"foo 1.0.0":  {"bar": "^1.0.0"},
+				"bar 1.0.0":  nil,
+				"bar 2.0.0":  nil,
+			},
+			want: map[string]string{"root": "1.0.0", "foo": "1.0.0", "bar": "1.0.0"},
+		},
+		{name: "avoiding conflict during decision making",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0": {"foo": "^1.0.0", "bar": "^1.0.0"},
+				"foo 1.1.0":  {"bar": "^2.0.0"},
+				"foo 1.0.0":  nil,
+				"bar 1.0.0":  nil,
+				"bar 1.1.0":  nil,
+				"bar 2.0.0":  nil,
+			},
+			want: map[string]string{"root": "1.0.0", "foo": "1.0.0", "bar": "1.1.0"},
+		},
+		{name: "performing conflict resolution",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0": {"foo": ">=1.0.0"},
+				"foo 2.0.0":  {"bar": "^1.0.0"},
+				"foo 1.0.0":  nil,
+				"bar 1.0.0":  {"foo": "^1.0.0"},
+			},
+			want: map[string]string{"root": "1.0.0", "foo": "1.0.0"},
+		},
+		{name: "conflict resolution with a partial satisfier",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0":   {"foo": "^1.0.0", "target": "^2.0.0"},
+				"foo 1.1.0":    {"left": "^1.0.0", "right": "^1.0.0"},
+				"foo 1.0.0":    nil,
+				"left 1.0.0":   {"shared": ">=1.0.0"},
+				"right 1.0.0":  {"shared": "<2.0.0"},
+				"shared 2.0.0": nil,
+				"shared 1.0.0": {"target": "^1.0.0"},
+				"target 2.0.0": nil,
+				"target 1.0.0": nil,
+			},
+			want: map[string]string{"root": "1.0.0", "foo": "1.0.0", "target": "2.0.0"},
+		},
+		{name: "release preferred over pre-release",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0":     {"foo": "^1.0.0"},
+				"foo 1.1.0":      nil,
+				"foo 1.2.0-rc.1": nil,
+			},
+			want: map[string]string{"root": "1.0.0", "foo": "1.1.0"},
+		},
+		{name: "pre-release when nothing else matches",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0":     {"foo": ">=1.2.0-0"},
+				"foo 1.1.0":      nil,
+				"foo 1.2.0-rc.1": nil,
+			},
+			want: map[string]string{"root": "1.0.0", "foo": "1.2.0-rc.1"},
+		},
+		{name: "rejecting a version with the maximum patch number",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0":                  {"foo": ">=1.0.0-0"},
+				"foo 1.2.9223372036854775807": {"bar": "^2.0.0"},
+				"foo 2.0.0-rc.1":              nil,
+				"bar 1.0.0":                   nil,
+			},
+			want: map[string]string{"root": "1.0.0", "foo": "2.0.0-rc.1"},
+		},
+		{name: "backtracking over several packages",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0": {"a": "*", "b": "*"},
+				"a 1.0.0":    {"x": "^1.0.0"},
+				"a 2.0.0":    {"x": "^2.0.0"},
+				"b 1.0.0":    {"x": "^1.0.0"},
+				"b 2.0.0":    {"x": "^3.0.0"},
+				"x 1.0.0":    nil,
+				"x 2.0.0":    nil,
+				"x 3.0.0":    nil,
+			},
+			want: map[string]string{"root": "1.0.0", "a": "1.0.0", "b": "1.0.0", "x": "1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(registry(t, tt.pkgs), "root", semver.SemVer{Major: 1})
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if want := versions(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Resolve() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResolve_NoSolution(t *testing.T) {
+	tests := []struct {
+		name string
+		pkgs map[string]map[string]string
+		want string
+	}{
+		{name: "no versions",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0": {"foo": "^2.0.0"},
+				"foo 1.0.0":  nil,
+			},
+			want: "Because root depends on foo ^2.0.0 and there is no version of foo in ^2.0.0, version solving failed.",
+		},
+		{name: "direct conflict",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0": {"a": "^1.0.0", "c": "*"},
+				"a 1.0.0":    {"b": "^2.0.0"},
+				"a 1.1.0":    {"b": "^2.0.0"},
+				"c 1.0.0":    {"b": "<2.0.0"},
+				"b 1.0.0":    nil,
+				"b 2.0.0":    nil,
+			},
+			want: "Because c depends on b <2.0.0 and a depends on b ^2.0.0, c and a are incompatible.\n" +
+				"And because root depends on a ^1.0.0 and root depends on c, version solving failed.",
+		},
+		{name: "linear",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0": {"foo": "^1.0.0", "baz": "^1.0.0"},
+				"foo 1.0.0":  {"bar": "^2.0.0"},
+				"bar 2.0.0":  {"baz": "^3.0.0"},
+				"baz 1.0.0":  nil,
+				"baz 3.0.0":  nil,
+			},
+			want: "Because foo depends on bar ^2.0.0 and bar depends on baz ^3.0.0, foo depends on baz ^3.0.0.\n" +
+				"And because root depends on baz ^1.0.0 and root depends on foo ^1.0.0, version solving failed.",
+		},
+		{name: "branching",
+			pkgs: map[string]map[string]string{
+				"root 1.0.0": {"foo": "^1.0.0"},
+				"foo 1.0.0":  {"a": "^1.0.0", "b": "^1.0.0"},
+				"foo 1.1.0":  {"x": "^1.0.0", "y": "^1.0.0"},
+				"a 1.0.0":    {"b": "^2.0.0"},
+				"b 1.0.0":    nil,
+				"b 2.0.0":    nil,
+				"x 1.0.0":    {"y": "^2.0.0"},
+				"y 1.0.0":    nil,
+				"y 2.0.0":    nil,
+			},
+			want: "Because a depends on b ^2.0.0 and foo <1.1.0 depends on a ^1.0.0, foo <1.1.0 depends on b ^2.0.0.\n" +
+				"And because foo <1.1.0 depends on b ^1.0.0 and root depends on foo ^1.0.0, foo ^1.1.0 is required. (1)\n" +
+				"\n" +
+				"Because x depends on y ^2.0.0 and foo >=1.1.0 depends on x ^1.0.0, foo >=1.1.0 depends on y ^2.0.0.\n" +
+				"And because foo >=1.1.0 depends on y ^1.0.0, foo >=1.1.0 is forbidden.\n" +
+				"And because foo ^1.1.0 is required (1), version solving failed.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Resolve(registry(t, tt.pkgs), "root", semver.SemVer{Major: 1})
+			var nse *NoSolutionError
+			if !errors.As(err, &nse) {
+				t.Fatalf("Resolve() error = %v, want *NoSolutionError", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Resolve() error =\n%v\nwant\n%v", err, tt.want)
+			}
+		})
+	}
+}
+
+type failingSource struct {
+	MemorySource
+}
+
+func (*failingSource) Versions(pkg string) ([]semver.SemVer, error) {
+	return nil, errors.New("registry is down")
+}
+
+func TestResolve_Errors(t *testing.T) {
+	if _, err := Resolve(&MemorySource{}, "root", semver.SemVer{Major: -1}); err == nil {
+		t.Errorf("Resolve() error = nil, want error")
+	}
+	if _, err := Resolve(&MemorySource{}, "root", semver.SemVer{Major: 1}); err == nil {
+		t.Errorf("Resolve() error = nil, want error for unknown root")
+	}
+	src := failingSource{MemorySource: *registry(t, map[string]map[string]string{"root 1.0.0": {"foo": "*"}})}
+	_, err := Resolve(&src, "root", semver.SemVer{Major: 1})
+	if err == nil || err.Error() != "registry is down" {
+		t.Errorf("Resolve() error = %v, want %v", err, "registry is down")
+	}
+}
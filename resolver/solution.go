@@ -0,0 +1,141 @@
+package resolver
+
+import (
+	"github.com/solsw/semver"
+)
+
+// assignment is either a decision (a selected version)
+// or a derivation (a term implied by an incompatibility).
+type assignment struct {
+	term
+	level    int
+	decision bool
+	// version is the selected version of a decision.
+	version semver.SemVer
+	// cause is the incompatibility the derivation is implied by; nil for decisions.
+	cause *incompatibility
+}
+
+// partialSolution is the ordered list of assignments made so far.
+type partialSolution struct {
+	assignments []assignment
+	decisions   map[string]semver.SemVer
+	// accumulated holds the intersection of all assignments per package.
+	accumulated map[string]term
+	level       int
+}
+
+func newPartialSolution() *partialSolution {
+	return &partialSolution{
+		decisions:   make(map[string]semver.SemVer),
+		accumulated: make(map[string]term),
+	}
+}
+
+func (ps *partialSolution) add(a assignment) {
+	ps.assignments = append(ps.assignments, a)
+	if acc, ok := ps.accumulated[a.pkg]; ok {
+		ps.accumulated[a.pkg] = acc.intersect(a.term)
+	} else {
+		ps.accumulated[a.pkg] = a.term
+	}
+}
+
+// decide selects version 'v' of 'pkg' at a new decision level.
+func (ps *partialSolution) decide(pkg string, v semver.SemVer) {
+	ps.level++
+	ps.decisions[pkg] = v
+	ps.add(assignment{
+		term:     term{pkg: pkg, positive: true, r: semver.ExactRange(v)},
+		level:    ps.level,
+		decision: true,
+		version:  v,
+	})
+}
+
+// derive adds 't' implied by 'cause' at the current decision level.
+func (ps *partialSolution) derive(t term, cause *incompatibility) {
+	ps.add(assignment{term: t, level: ps.level, cause: cause})
+}
+
+// backtrack removes all assignments made after decision level 'level'.
+func (ps *partialSolution) backtrack(level int) {
+	kept := ps.assignments
+	ps.assignments = nil
+	ps.decisions = make(map[string]semver.SemVer)
+	ps.accumulated = make(map[string]term)
+	for _, a := range kept {
+		if a.level > level {
+			break
+		}
+		ps.add(a)
+		if a.decision {
+			ps.decisions[a.pkg] = a.version
+		}
+	}
+	ps.level = level
+}
+
+// relation is the relation of a term or an incompatibility to the partial solution.
+type relation int
+
+const (
+	satisfied relation = iota
+	contradicted
+	inconclusive
+	// almostSatisfied: all terms but one of an incompatibility are satisfied,
+	// and the remaining one is inconclusive.
+	almostSatisfied
+)
+
+func (ps *partialSolution) termRelation(t term) relation {
+	acc, ok := ps.accumulated[t.pkg]
+	if !ok {
+		// nothing is known about the package, any term is possible
+		acc = term{pkg: t.pkg}
+	}
+	if acc.subsetOf(t) {
+		return satisfied
+	}
+	if acc.disjoint(t) {
+		return contradicted
+	}
+	return inconclusive
+}
+
+// relation returns the relation of 'inc' to the partial solution.
+// If 'inc' is almost satisfied, its inconclusive term is returned too.
+func (ps *partialSolution) relation(inc *incompatibility) (relation, term) {
+	var unsatisfied *term
+	for i, t := range inc.terms {
+		switch ps.termRelation(t) {
+		case contradicted:
+			return contradicted, term{}
+		case inconclusive:
+			if unsatisfied != nil {
+				return inconclusive, term{}
+			}
+			unsatisfied = &inc.terms[i]
+		}
+	}
+	if unsatisfied == nil {
+		return satisfied, term{}
+	}
+	return almostSatisfied, *unsatisfied
+}
+
+// satisfier returns the index of the earliest assignment
+// after which the partial solution satisfies 't'.
+func (ps *partialSolution) satisfier(t term) int {
+	acc := term{pkg: t.pkg}
+	for i, a := range ps.assignments {
+		if a.pkg != t.pkg {
+			continue
+		}
+		acc = acc.intersect(a.term)
+		if acc.subsetOf(t) {
+			return i
+		}
+	}
+	panic("resolver: term " + t.String() + " is not satisfied")
+}
@@ -0,0 +1,54 @@
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/solsw/semver"
+)
+
+// Source provides available versions of packages and their dependencies.
+type Source interface {
+	// Versions returns all available versions of 'pkg' in any order.
+	// An unknown package has no versions.
+	Versions(pkg string) ([]semver.SemVer, error)
+	// Dependencies returns the dependencies of version 'v' of 'pkg'
+	// as version ranges keyed by package name.
+	Dependencies(pkg string, v semver.SemVer) (map[string]semver.Range, error)
+}
+
+// MemorySource is an in-memory [Source].
+// MemorySource's zero value is an empty source ready to use.
+// MemorySource is not safe for concurrent use while packages are added.
+type MemorySource struct {
+	pkgs map[string]map[semver.SemVer]map[string]semver.Range
+}
+
+// Add adds version 'v' of 'pkg' with dependencies 'deps'.
+// Adding an existing version replaces its dependencies.
+func (s *MemorySource) Add(pkg string, v semver.SemVer, deps map[string]semver.Range) {
+	if s.pkgs == nil {
+		s.pkgs = make(map[string]map[semver.SemVer]map[string]semver.Range)
+	}
+	if s.pkgs[pkg] == nil {
+		s.pkgs[pkg] = make(map[semver.SemVer]map[string]semver.Range)
+	}
+	s.pkgs[pkg][v] = deps
+}
+
+// Versions implements the [Source] interface.
+func (s *MemorySource) Versions(pkg string) ([]semver.SemVer, error) {
+	vv := make([]semver.SemVer, 0, len(s.pkgs[pkg]))
+	for v := range s.pkgs[pkg] {
+		vv = append(vv, v)
+	}
+	return vv, nil
+}
+
+// Dependencies implements the [Source] interface.
+func (s *MemorySource) Dependencies(pkg string, v semver.SemVer) (map[string]semver.Range, error) {
+	deps, ok := s.pkgs[pkg][v]
+	if !ok {
+		return nil, fmt.Errorf("unknown version %s of %s", v, pkg)
+	}
+	return deps, nil
+}
@@ -0,0 +1,69 @@
+package resolver
+
+import (
+	"github.com/solsw/semver"
+)
+
+// term is a statement about a package: a positive term means that a version
+// of the package in the range is selected, a negative term means that
+// either no version of the package is selected or the selected one is not in the range.
+type term struct {
+	pkg      string
+	positive bool
+	r        semver.Range
+}
+
+func (t term) negate() term {
+	return term{pkg: t.pkg, positive: !t.positive, r: t.r}
+}
+
+// intersect returns the term satisfied exactly when both 't' and 'o' are.
+// 't' and 'o' must refer to the same package.
+func (t term) intersect(o term) term {
+	switch {
+	case t.positive && o.positive:
+		return term{pkg: t.pkg, positive: true, r: t.r.Intersect(o.r)}
+	case t.positive:
+		return term{pkg: t.pkg, positive: true, r: t.r.Difference(o.r)}
+	case o.positive:
+		return term{pkg: t.pkg, positive: true, r: o.r.Difference(t.r)}
+	}
+	return term{pkg: t.pkg, r: t.r.Union(o.r)}
+}
+
+// union returns the term satisfied when 't' or 'o' is.
+// 't' and 'o' must refer to the same package.
+func (t term) union(o term) term {
+	return t.negate().intersect(o.negate()).negate()
+}
+
+// isEmpty reports whether 't' can never be satisfied.
+// A negative term is always satisfied when its package is not selected.
+func (t term) isEmpty() bool {
+	return t.positive && t.r.IsEmpty()
+}
+
+// subsetOf reports whether 't' satisfied implies 'o' satisfied.
+func (t term) subsetOf(o term) bool {
+	return t.intersect(o.negate()).isEmpty()
+}
+
+// disjoint reports whether 't' and 'o' cannot be satisfied together.
+func (t term) disjoint(o term) bool {
+	return t.intersect(o).isEmpty()
+}
+
+// String returns the term in the form used in explanations.
+func (t term) String() string {
+	if t.positive {
+		return pkgRange(t.pkg, t.r)
+	}
+	return "not " + pkgRange(t.pkg, t.r)
+}
+
+func pkgRange(pkg string, r semver.Range) string {
+	if r.IsAny() {
+		return pkg
+	}
+	return pkg + " " + r.String()
+}
@@ -0,0 +1,73 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/solsw/semver"
+)
+
+func newTerm(positive bool, r string) term {
+	rr, _ := semver.ParseRange(r)
+	return term{pkg: "p", positive: positive, r: rr}
+}
+
+func TestTerm_Intersect(t *testing.T) {
+	tests := []struct {
+		name   string
+		t1, t2 term
+		want   term
+	}{
+		{name: "1", t1: newTerm(true, "^1.0.0"), t2: newTerm(true, ">=1.5.0"), want: newTerm(true, "^1.5.0")},
+		{name: "2", t1: newTerm(true, "^1.0.0"), t2: newTerm(false, ">=1.5.0"), want: newTerm(true, ">=1.0.0 <1.5.0")},
+		{name: "3", t1: newTerm(false, "^1.0.0"), t2: newTerm(true, "*"), want: newTerm(true, "<1.0.0 || >=2.0.0-0")},
+		{name: "4", t1: newTerm(false, "^1.0.0"), t2: newTerm(false, "^2.0.0"), want: newTerm(false, "^1.0.0 || ^2.0.0")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.t1.intersect(tt.t2)
+			if got.positive != tt.want.positive || !got.r.Equal(tt.want.r) {
+				t.Errorf("term.intersect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerm_Relations(t *testing.T) {
+	tests := []struct {
+		name         string
+		t1, t2       term
+		wantSubset   bool
+		wantDisjoint bool
+	}{
+		{name: "1", t1: newTerm(true, "^1.2.0"), t2: newTerm(true, "^1.0.0"), wantSubset: true},
+		{name: "2", t1: newTerm(true, "^1.0.0"), t2: newTerm(true, "^1.2.0")},
+		{name: "3", t1: newTerm(true, "^1.0.0"), t2: newTerm(true, "^2.0.0"), wantDisjoint: true},
+		{name: "4", t1: newTerm(true, "^1.0.0"), t2: newTerm(false, "^2.0.0"), wantSubset: true},
+		{name: "5", t1: newTerm(false, "^2.0.0"), t2: newTerm(true, "^1.0.0")},
+		{name: "6", t1: newTerm(false, "^1.0.0"), t2: newTerm(false, "^2.0.0")},
+		{name: "7", t1: newTerm(false, "*"), t2: newTerm(false, "^1.0.0"), wantSubset: true},
+		{name: "8", t1: newTerm(true, "<0.0.0-0"), t2: newTerm(true, "^1.0.0"), wantSubset: true, wantDisjoint: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t1.subsetOf(tt.t2); got != tt.wantSubset {
+				t.Errorf("term.subsetOf() = %v, want %v", got, tt.wantSubset)
+			}
+			if got := tt.t1.disjoint(tt.t2); got != tt.wantDisjoint {
+				t.Errorf("term.disjoint() = %v, want %v", got, tt.wantDisjoint)
+			}
+		})
+	}
+}
+
+func TestTerm_String(t *testing.T) {
+	if got := newTerm(true, "*").String(); got != "p" {
+		t.Errorf("term.String() = %v, want %v", got, "p")
+	}
+	if got := newTerm(false, "^1.0.0").String(); got != "not p ^1.0.0" {
+		t.Errorf("term.String() = %v, want %v", got, "not p ^1.0.0")
+	}
+	if got := newTerm(true, "^1.0.0").union(newTerm(true, "^2.0.0")).String(); got != "p ^1.0.0 || ^2.0.0" {
+		t.Errorf("term.union() = %v, want %v", got, "p ^1.0.0 || ^2.0.0")
+	}
+}
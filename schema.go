@@ -0,0 +1,100 @@
+package semver
+
+import (
+	"regexp"
+)
+
+const (
+	// numberPattern matches version numbers from 0 to math.MaxInt64 without leading zeros.
+	numberPattern = `0|[1-9]\d{0,17}|[1-8]\d{18}|9[0-1]\d{17}|92[0-1]\d{16}|922[0-2]\d{15}|9223[0-2]\d{14}|` +
+		`92233[0-6]\d{13}|922337[0-1]\d{12}|92233720[0-2]\d{10}|922337203[0-5]\d{9}|9223372036[0-7]\d{8}|` +
+		`92233720368[0-4]\d{7}|922337203685[0-3]\d{6}|9223372036854[0-6]\d{5}|92233720368547[0-6]\d{4}|` +
+		`922337203685477[0-4]\d{3}|9223372036854775[0-7]\d{2}|922337203685477580[0-6]|9223372036854775807`
+	preReleaseIdentifierPattern = `0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*`
+	// preReleasePattern matches pre-release versions (without the leading '-').
+	preReleasePattern = `(?:` + preReleaseIdentifierPattern + `)(?:\.(?:` + preReleaseIdentifierPattern + `))*`
+	// buildPattern matches build metadata (without the leading '+').
+	buildPattern = `[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*`
+)
+
+// Pattern is the regular expression matching exactly the version strings accepted by [Parse]:
+// the [regular expression suggested by semver.org] with version numbers limited to int64.
+// Submatches are major, minor and patch versions, pre-release version and build metadata.
+// The syntax is compatible with RE2 ([regexp]) and ECMA-262 (JSON Schema, OpenAPI).
+//
+// [regular expression suggested by semver.org]: https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
+const Pattern = `^(` + numberPattern + `)\.(` + numberPattern + `)\.(` + numberPattern + `)` +
+	`(?:-(` + preReleasePattern + `))?(?:\+(` + buildPattern + `))?$`
+
+// Regexp is the compiled [Pattern].
+var Regexp = regexp.MustCompile(Pattern)
+
+// SchemaDialect is the dialect of schemas returned by [VersionSchema],
+// [VersionObjectSchema] and [RangeSchema].
+type SchemaDialect int
+
+const (
+	// JSONSchema is JSON Schema 2020-12, also used by OpenAPI 3.1.
+	JSONSchema SchemaDialect = iota
+	// OpenAPI30 is the schema object of OpenAPI 3.0.
+	OpenAPI30
+)
+
+// example adds the 'examples' to 's' in the form of dialect 'd'.
+func (d SchemaDialect) example(s map[string]any, examples ...string) map[string]any {
+	if d == OpenAPI30 {
+		s["example"] = examples[0]
+	} else {
+		s["examples"] = examples
+	}
+	return s
+}
+
+// VersionSchema returns the schema of a version string ([SemVer] marshalled as text).
+// The result may be marshalled to JSON or embedded into a larger schema.
+func VersionSchema(d SchemaDialect) map[string]any {
+	return d.example(map[string]any{
+		"type":        "string",
+		"description": "Semantic version (https://semver.org/)",
+		"pattern":     Pattern,
+	}, "1.2.3", "1.0.0-rc.1+build.5")
+}
+
+// VersionObjectSchema returns the schema of a version object ([SemVer] marshalled as [Object]).
+// The result may be marshalled to JSON or embedded into a larger schema.
+func VersionObjectSchema(d SchemaDialect) map[string]any {
+	number := func(description string) map[string]any {
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0, "description": description}
+	}
+	return map[string]any{
+		"type":        "object",
+		"description": "Semantic version (https://semver.org/)",
+		"properties": map[string]any{
+			"major": number("Major version"),
+			"minor": number("Minor version"),
+			"patch": number("Patch version"),
+			"prerelease": d.example(map[string]any{
+				"type":        "string",
+				"description": "Pre-release version",
+				"pattern":     `^` + preReleasePattern + `$`,
+			}, "rc.1"),
+			"build": d.example(map[string]any{
+				"type":        "string",
+				"description": "Build metadata",
+				"pattern":     `^` + buildPattern + `$`,
+			}, "build.5"),
+		},
+		"required":             []string{"major", "minor", "patch"},
+		"additionalProperties": false,
+	}
+}
+
+// RangeSchema returns the schema of a range string ([Range] marshalled as text, see [ParseRange]).
+// The range syntax is not checked by the schema.
+// The result may be marshalled to JSON or embedded into a larger schema.
+func RangeSchema(d SchemaDialect) map[string]any {
+	return d.example(map[string]any{
+		"type":        "string",
+		"description": "Version range (npm syntax, e.g. \"^1.2\", \">=1.2.0 <2.0.0\", \"1.x || ~2.3\")",
+	}, "^1.2.3", ">=1.2.0 <2.0.0-0")
+}
@@ -0,0 +1,189 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// genNumberPattern returns the alternation matching decimal numbers from 0 to 'max' without leading zeros.
+func genNumberPattern(max string) string {
+	parts := []string{"0", fmt.Sprintf(`[1-9]\d{0,%d}`, len(max)-2)}
+	for i := range len(max) {
+		lo, hi := 0, int(max[i]-'0')-1
+		if i == 0 {
+			lo = 1
+		}
+		if hi < lo {
+			continue
+		}
+		p := max[:i] + strconv.Itoa(lo)
+		if lo != hi {
+			p = fmt.Sprintf("%s[%d-%d]", max[:i], lo, hi)
+		}
+		switch rest := len(max) - i - 1; rest {
+		case 0:
+		case 1:
+			p += `\d`
+		default:
+			p += fmt.Sprintf(`\d{%d}`, rest)
+		}
+		parts = append(parts, p)
+	}
+	return strings.Join(append(parts, max), "|")
+}
+
+func TestNumberPattern(t *testing.T) {
+	if got := genNumberPattern(strconv.FormatInt(math.MaxInt64, 10)); got != numberPattern {
+		t.Errorf("numberPattern = %s, want %s", numberPattern, got)
+	}
+	re := regexp.MustCompile(`^(?:` + numberPattern + `)$`)
+	for _, s := range []string{"0", "1", "9", "10", "999999999999999999", "1000000000000000000",
+		"8999999999999999999", "9223372036854775806", "9223372036854775807", "9223372036854775799", "9223372036854775000"} {
+		if !re.MatchString(s) {
+			t.Errorf("numberPattern does not match %s", s)
+		}
+	}
+	for _, s := range []string{"", "00", "01", "-1", "9223372036854775808", "9223372036854775810", "9223372036854776000",
+		"9300000000000000000", "10000000000000000000", "99999999999999999999"} {
+		if re.MatchString(s) {
+			t.Errorf("numberPattern matches %s", s)
+		}
+	}
+}
+
+// checkPattern checks that Regexp agrees with Parse on 's' including the submatches.
+func checkPattern(t *testing.T, s string) {
+	t.Helper()
+	m := Regexp.FindStringSubmatch(s)
+	v, err := Parse(s)
+	if (m != nil) != (err == nil) {
+		t.Errorf("Regexp match %q = %v, Parse() error = %v", s, m != nil, err)
+		return
+	}
+	if m == nil {
+		return
+	}
+	got := fmt.Sprintf("%s.%s.%s|%s|%s", m[1], m[2], m[3], m[4], m[5])
+	want := fmt.Sprintf("%d.%d.%d|%s|%s", v.Major, v.Minor, v.Patch, v.PreRelease, v.Build)
+	if got != want {
+		t.Errorf("Regexp submatches of %q = %s, want %s", s, got, want)
+	}
+}
+
+func TestPattern(t *testing.T) {
+	var ss []string
+	ss = append(ss, readCorpus(t, "valid.txt")...)
+	ss = append(ss, readCorpus(t, "invalid.txt")...)
+	for _, v := range strictVersions(2000) {
+		ss = append(ss, v.String())
+	}
+	for _, n := range []string{"9223372036854775807", "9223372036854775808", "18446744073709551615", "09"} {
+		ss = append(ss, n+".0.0", "0."+n+".0", "0.0."+n, "0.0."+n+"-"+n)
+	}
+	for _, s := range ss {
+		checkPattern(t, s)
+		for _, mutated := range []string{"v" + s, s + "\n", s + "-", s + "+", s + ".0", s + "+é", s + "-a_b"} {
+			checkPattern(t, mutated)
+		}
+	}
+	if !strings.HasPrefix(Pattern, "^") || !strings.HasSuffix(Pattern, "$") {
+		t.Errorf("Pattern is not anchored")
+	}
+}
+
+func TestVersionSchema(t *testing.T) {
+	for _, d := range []SchemaDialect{JSONSchema, OpenAPI30} {
+		b, err := json.Marshal(VersionSchema(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s struct {
+			Type     string   `json:"type"`
+			Pattern  string   `json:"pattern"`
+			Example  string   `json:"example"`
+			Examples []string `json:"examples"`
+		}
+		if err := json.Unmarshal(b, &s); err != nil {
+			t.Fatal(err)
+		}
+		if s.Type != "string" || s.Pattern != Pattern {
+			t.Errorf("VersionSchema(%d) = %s", d, b)
+		}
+		examples := s.Examples
+		if d == OpenAPI30 {
+			examples = []string{s.Example}
+		}
+		if len(examples) == 0 {
+			t.Errorf("VersionSchema(%d) has no examples", d)
+		}
+		for _, e := range examples {
+			if _, err := Parse(e); err != nil {
+				t.Errorf("VersionSchema(%d) example %q: %v", d, e, err)
+			}
+		}
+	}
+}
+
+func TestVersionObjectSchema(t *testing.T) {
+	s := VersionObjectSchema(JSONSchema)
+	props := s["properties"].(map[string]any)
+	for _, name := range []string{"major", "minor", "patch", "prerelease", "build"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("VersionObjectSchema() has no property %q", name)
+		}
+	}
+	// the property names are those of Object
+	b, _ := json.Marshal(Object(MustParse("1.2.3-rc.1+b")))
+	var obj map[string]any
+	json.Unmarshal(b, &obj)
+	for name := range obj {
+		if _, ok := props[name]; !ok {
+			t.Errorf("Object property %q is not in VersionObjectSchema()", name)
+		}
+	}
+	for name, valid := range map[string][]string{
+		"prerelease": {"rc.1", "0", "alpha-1.x"},
+		"build":      {"b", "001", "a.b-c"},
+	} {
+		re := regexp.MustCompile(props[name].(map[string]any)["pattern"].(string))
+		for _, s := range valid {
+			if !re.MatchString(s) {
+				t.Errorf("%s pattern does not match %q", name, s)
+			}
+		}
+		for _, s := range []string{"", "a..b", ".a", "a_b"} {
+			if re.MatchString(s) {
+				t.Errorf("%s pattern matches %q", name, s)
+			}
+		}
+	}
+	if re := regexp.MustCompile(props["prerelease"].(map[string]any)["pattern"].(string)); re.MatchString("01") {
+		t.Errorf("prerelease pattern matches %q", "01")
+	}
+	for _, d := range []SchemaDialect{JSONSchema, OpenAPI30} {
+		major := VersionObjectSchema(d)["properties"].(map[string]any)["major"].(map[string]any)
+		if major["format"] != "int64" {
+			t.Errorf("VersionObjectSchema(%d) major format = %v, want int64", d, major["format"])
+		}
+		if _, ok := major["maximum"]; ok {
+			t.Errorf("VersionObjectSchema(%d) major has maximum", d)
+		}
+	}
+}
+
+func TestRangeSchema(t *testing.T) {
+	s := RangeSchema(JSONSchema)
+	for _, e := range s["examples"].([]string) {
+		if _, err := ParseRange(e); err != nil {
+			t.Errorf("RangeSchema() example %q: %v", e, err)
+		}
+	}
+	if _, ok := RangeSchema(OpenAPI30)["example"]; !ok {
+		t.Errorf("RangeSchema(OpenAPI30) has no example")
+	}
+}
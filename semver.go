@@ -89,6 +89,7 @@ func (v SemVer) LessThan(other SemVer) (bool, error) {
 }
 
 // EqualTo reports whether 'v' is [equal] to 'other'.
+// Build metadata is ignored; use [Identical] to tell apart versions differing only in build metadata.
 //
 // [equal]: https://semver.org/#spec-item-11
 func (v SemVer) EqualTo(other SemVer) (bool, error) {
@@ -0,0 +1,5 @@
+// Package semvertest provides random versions and assertions for testing code built on [semver.SemVer].
+//
+// A [Generator] produces reproducible sequences of spec-valid versions and of near-miss
+// invalid version strings. [Version] and [Invalid] implement [quick.Generator].
+package semvertest
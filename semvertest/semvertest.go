@@ -0,0 +1,243 @@
+package semvertest
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/solsw/semver"
+)
+
+// Config controls the distribution of generated versions.
+type Config struct {
+	// PreRelease is the probability of a pre-release version.
+	PreRelease float64
+	// Build is the probability of build metadata.
+	Build float64
+	// MaxIdentifiers is the maximum number of identifiers of a pre-release version or build metadata.
+	MaxIdentifiers int
+	// Edge is the probability that a version number or a numeric identifier
+	// is an edge case: 0, 1 or close to [math.MaxInt64].
+	Edge float64
+}
+
+// DefaultConfig is the [Config] used if none is given.
+var DefaultConfig = Config{PreRelease: 0.3, Build: 0.2, MaxIdentifiers: 3, Edge: 0.05}
+
+// Generator generates random versions.
+// A Generator is not safe for concurrent use.
+type Generator struct {
+	r *rand.Rand
+	c Config
+}
+
+// New returns a [Generator] seeded with 'seed' generating versions according to 'c'.
+// A nil 'c' means [DefaultConfig].
+func New(seed int64, c *Config) *Generator {
+	return newGenerator(rand.New(rand.NewSource(seed)), c)
+}
+
+func newGenerator(r *rand.Rand, c *Config) *Generator {
+	g := &Generator{r: r, c: DefaultConfig}
+	if c != nil {
+		g.c = *c
+	}
+	if g.c.MaxIdentifiers < 1 {
+		g.c.MaxIdentifiers = 1
+	}
+	return g
+}
+
+func (g *Generator) number() int64 {
+	if g.r.Float64() < g.c.Edge {
+		return []int64{0, 1, math.MaxInt64 - 1, math.MaxInt64}[g.r.Intn(4)]
+	}
+	if g.r.Intn(4) == 0 {
+		return g.r.Int63n(1000)
+	}
+	return g.r.Int63n(10)
+}
+
+const (
+	alnum   = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-"
+	letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-"
+)
+
+var labels = []string{"alpha", "beta", "rc", "dev", "pre", "SNAPSHOT", "x-y"}
+
+func (g *Generator) alnumIdent() string {
+	if g.r.Intn(2) == 0 {
+		return labels[g.r.Intn(len(labels))]
+	}
+	n := 1 + g.r.Intn(8)
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alnum[g.r.Intn(len(alnum))]
+	}
+	// at least one non-digit makes the identifier alphanumeric
+	b[g.r.Intn(n)] = letters[g.r.Intn(len(letters))]
+	return string(b)
+}
+
+func (g *Generator) identifiers(preRelease bool) string {
+	n := 1 + g.r.Intn(g.c.MaxIdentifiers)
+	ss := make([]string, n)
+	for i := range ss {
+		switch {
+		case g.r.Intn(2) == 0:
+			ss[i] = g.alnumIdent()
+		case preRelease:
+			ss[i] = strconv.FormatInt(g.number(), 10)
+		default:
+			// build identifiers may have leading zeros
+			ss[i] = strings.Repeat("0", g.r.Intn(2)) + strconv.FormatInt(g.number(), 10)
+		}
+	}
+	return strings.Join(ss, ".")
+}
+
+// Next returns a random spec-valid version.
+func (g *Generator) Next() semver.SemVer {
+	v := semver.SemVer{Major: g.number(), Minor: g.number(), Patch: g.number()}
+	if g.r.Float64() < g.c.PreRelease {
+		v.PreRelease = g.identifiers(true)
+	}
+	if g.r.Float64() < g.c.Build {
+		v.Build = g.identifiers(false)
+	}
+	return v
+}
+
+// NextN returns 'n' random spec-valid versions.
+func (g *Generator) NextN(n int) []semver.SemVer {
+	vv := make([]semver.SemVer, n)
+	for i := range vv {
+		vv[i] = g.Next()
+	}
+	return vv
+}
+
+// mutations turn a valid version string into a near-miss invalid one (or leave it valid).
+var mutations = []func(g *Generator, s string) string{
+	// leading zero in a version number
+	func(g *Generator, s string) string {
+		i := strings.IndexByte(s, '.')
+		if g.r.Intn(2) == 0 {
+			i = 0
+		} else {
+			i++
+		}
+		return s[:i] + "0" + s[i:]
+	},
+	// missing version number
+	func(g *Generator, s string) string {
+		i := strings.LastIndexByte(s[:strings.IndexAny(s+"-+", "-+")], '.')
+		return s[:i] + strings.TrimLeft(s[i+1:], "0123456789")
+	},
+	// extra version number
+	func(g *Generator, s string) string {
+		i := strings.IndexAny(s+"-+", "-+")
+		return s[:i] + ".0" + s[i:]
+	},
+	// leading "v" or surrounding whitespace
+	func(g *Generator, s string) string {
+		return []string{"v" + s, " " + s, s + " ", s + "\n"}[g.r.Intn(4)]
+	},
+	// empty pre-release version or build metadata
+	func(g *Generator, s string) string {
+		return s + []string{"-", "+", "-+", "+b+"}[g.r.Intn(4)]
+	},
+	// empty identifier
+	func(g *Generator, s string) string {
+		return s + []string{"-a..b", "+a..b", "-a.", "+.b"}[g.r.Intn(4)]
+	},
+	// invalid character
+	func(g *Generator, s string) string {
+		c := []string{"_", "é", "!", " ", "\x00"}[g.r.Intn(5)]
+		if i := strings.IndexAny(s, "-+"); i >= 0 && g.r.Intn(2) == 0 {
+			return s[:i+1] + c + s[i+1:]
+		}
+		return s + "-a" + c
+	},
+	// numeric pre-release identifier with leading zero
+	func(g *Generator, s string) string {
+		if i := strings.IndexByte(s, '+'); i >= 0 {
+			s = s[:i]
+		}
+		if strings.IndexByte(s, '-') >= 0 {
+			return s + ".0" + strconv.Itoa(g.r.Intn(100))
+		}
+		return s + "-0" + strconv.Itoa(g.r.Intn(100))
+	},
+	// version number overflowing int64 (spec-valid, but not representable by semver.SemVer)
+	func(g *Generator, s string) string {
+		return "9223372036854775808" + s[strings.IndexByte(s, '.'):]
+	},
+}
+
+// NextInvalid returns a random string that is close to a valid version
+// but rejected by [semver.Parse], e.g. with a leading zero, an empty identifier,
+// an invalid character or a version number overflowing int64.
+func (g *Generator) NextInvalid() string {
+	for {
+		s := mutations[g.r.Intn(len(mutations))](g, g.Next().String())
+		if _, err := semver.Parse(s); err != nil {
+			return s
+		}
+	}
+}
+
+// Version is a [semver.SemVer] implementing [quick.Generator] with [DefaultConfig].
+type Version struct {
+	semver.SemVer
+}
+
+// Generate implements the [quick.Generator] interface.
+func (Version) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(Version{newGenerator(r, nil).Next()})
+}
+
+// Invalid is a near-miss invalid version string (see [Generator.NextInvalid])
+// implementing [quick.Generator].
+type Invalid string
+
+// Generate implements the [quick.Generator] interface.
+func (Invalid) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(Invalid(newGenerator(r, nil).NextInvalid()))
+}
+
+var (
+	_ quick.Generator = Version{}
+	_ quick.Generator = Invalid("")
+)
+
+// AssertValid reports an error for every invalid version of 'vv'.
+func AssertValid(t testing.TB, vv ...semver.SemVer) {
+	t.Helper()
+	for i, v := range vv {
+		if err := semver.Valid(v); err != nil {
+			t.Errorf("version %d (%v) is invalid: %v", i, v, err)
+		}
+	}
+}
+
+// AssertOrdered reports an error if 'vv' is not in ascending order by [semver.Compare]
+// (versions of equal precedence may be adjacent) or contains an invalid version.
+func AssertOrdered(t testing.TB, vv []semver.SemVer) {
+	t.Helper()
+	for i := 1; i < len(vv); i++ {
+		r, err := semver.Compare(vv[i-1], vv[i])
+		if err != nil {
+			t.Errorf("versions %d and %d (%v, %v): %v", i-1, i, vv[i-1], vv[i], err)
+			return
+		}
+		if r > 0 {
+			t.Errorf("versions %d and %d are out of order: %v > %v", i-1, i, vv[i-1], vv[i])
+			return
+		}
+	}
+}
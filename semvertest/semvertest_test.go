@@ -0,0 +1,147 @@
+package semvertest
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/solsw/semver"
+)
+
+func TestGenerator_Next(t *testing.T) {
+	vv := New(1, nil).NextN(1000)
+	AssertValid(t, vv...)
+	if !reflect.DeepEqual(vv, New(1, nil).NextN(1000)) {
+		t.Errorf("NextN() is not reproducible")
+	}
+	if reflect.DeepEqual(vv, New(2, nil).NextN(1000)) {
+		t.Errorf("NextN() does not depend on seed")
+	}
+	var pre, build int
+	for _, v := range vv {
+		if len(v.PreRelease) > 0 {
+			pre++
+		}
+		if len(v.Build) > 0 {
+			build++
+		}
+	}
+	if pre < 200 || pre > 400 || build < 100 || build > 300 {
+		t.Errorf("NextN() pre-release = %d, build = %d of 1000", pre, build)
+	}
+}
+
+func TestGenerator_Next_Config(t *testing.T) {
+	tests := []struct {
+		name  string
+		c     Config
+		check func(semver.SemVer) bool
+	}{
+		{name: "release only",
+			c:     Config{},
+			check: func(v semver.SemVer) bool { return len(v.PreRelease) == 0 && len(v.Build) == 0 },
+		},
+		{name: "always pre-release and build",
+			c:     Config{PreRelease: 1, Build: 1},
+			check: func(v semver.SemVer) bool { return len(v.PreRelease) > 0 && len(v.Build) > 0 },
+		},
+		{name: "edge cases",
+			c: Config{Edge: 1},
+			check: func(v semver.SemVer) bool {
+				return slices.Contains([]int64{0, 1, 1<<63 - 2, 1<<63 - 1}, v.Major)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(1, &tt.c)
+			for range 100 {
+				v := g.Next()
+				AssertValid(t, v)
+				if !tt.check(v) {
+					t.Fatalf("Next() = %v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_NextInvalid(t *testing.T) {
+	g := New(1, nil)
+	for range 1000 {
+		s := g.NextInvalid()
+		if _, err := semver.Parse(s); err == nil {
+			t.Fatalf("NextInvalid() = %q is valid", s)
+		}
+	}
+}
+
+func TestQuick(t *testing.T) {
+	roundTrip := func(v Version) bool {
+		p, err := semver.Parse(v.String())
+		return err == nil && p == v.SemVer
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+	rejected := func(s Invalid) bool {
+		_, err := semver.Parse(string(s))
+		return err != nil
+	}
+	if err := quick.Check(rejected, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// recorder records errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertOrdered(t *testing.T) {
+	tests := []struct {
+		name string
+		vv   []semver.SemVer
+		want []string
+	}{
+		{name: "empty"},
+		{name: "ordered",
+			vv: []semver.SemVer{{Major: 1, PreRelease: "rc.1"}, {Major: 1}, {Major: 1, Build: "b"}, {Major: 2}},
+		},
+		{name: "out of order",
+			vv:   []semver.SemVer{{Major: 1}, {Major: 3}, {Major: 2}},
+			want: []string{"versions 1 and 2 are out of order: 3.0.0 > 2.0.0"},
+		},
+		{name: "invalid",
+			vv:   []semver.SemVer{{Major: 1}, {Major: -1}},
+			want: []string{"versions 0 and 1 (1.0.0, -1.0.0): malformed semver"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			AssertOrdered(r, tt.vv)
+			if !reflect.DeepEqual(r.errors, tt.want) {
+				t.Errorf("AssertOrdered() errors = %q, want %q", r.errors, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssertOrdered_Sorted(t *testing.T) {
+	vv := New(3, nil).NextN(500)
+	slices.SortFunc(vv, func(a, b semver.SemVer) int {
+		r, _ := semver.Compare(a, b)
+		return r
+	})
+	AssertOrdered(t, vv)
+}
@@ -0,0 +1,118 @@
+package semver
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// LogValue implements the [slog.LogValuer] interface.
+// LogValue returns a group with the "major", "minor", "patch", "prerelease", "build"
+// and "full" (the [SemVer.String] form) attributes,
+// so log stores can query the version components.
+// Use [NewLogHandler] with LogHandlerOptions.Plain to log versions as plain strings.
+func (v SemVer) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("major", v.Major),
+		slog.Int64("minor", v.Minor),
+		slog.Int64("patch", v.Patch),
+		slog.String("prerelease", v.PreRelease),
+		slog.String("build", v.Build),
+		slog.String("full", v.String()),
+	)
+}
+
+// LogHandlerOptions are options for [NewLogHandler].
+type LogHandlerOptions struct {
+	// Keys lists the keys of string attributes holding versions.
+	// Such attributes with valid versions are logged like [SemVer] values.
+	Keys []string
+	// Plain makes [SemVer] and [*SemVer] values be logged as strings instead of groups.
+	// A nil [*SemVer] is logged as an empty string or an empty group.
+	Plain bool
+}
+
+type logHandler struct {
+	h    slog.Handler
+	opts LogHandlerOptions
+}
+
+// NewLogHandler returns a [slog.Handler] passing records to 'h'
+// with version attributes normalised according to 'opts'.
+// A nil 'opts' is the same as the zero [LogHandlerOptions].
+func NewLogHandler(h slog.Handler, opts *LogHandlerOptions) slog.Handler {
+	lh := &logHandler{h: h}
+	if opts != nil {
+		lh.opts = *opts
+	}
+	return lh
+}
+
+// Enabled implements the [slog.Handler] interface.
+func (lh *logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return lh.h.Enabled(ctx, level)
+}
+
+// Handle implements the [slog.Handler] interface.
+func (lh *logHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(lh.normalize(a))
+		return true
+	})
+	return lh.h.Handle(ctx, nr)
+}
+
+// WithAttrs implements the [slog.Handler] interface.
+func (lh *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	aa := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		aa[i] = lh.normalize(a)
+	}
+	return &logHandler{h: lh.h.WithAttrs(aa), opts: lh.opts}
+}
+
+// WithGroup implements the [slog.Handler] interface.
+func (lh *logHandler) WithGroup(name string) slog.Handler {
+	return &logHandler{h: lh.h.WithGroup(name), opts: lh.opts}
+}
+
+func (lh *logHandler) normalize(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		gg := a.Value.Group()
+		aa := make([]slog.Attr, len(gg))
+		for i, g := range gg {
+			aa[i] = lh.normalize(g)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(aa...)}
+	case slog.KindLogValuer:
+		switch v := a.Value.Any().(type) {
+		case SemVer:
+			return lh.version(a.Key, v)
+		case *SemVer:
+			// nil is logged as an empty value instead of panicking in LogValue
+			if v == nil {
+				if lh.opts.Plain {
+					return slog.String(a.Key, "")
+				}
+				return slog.Attr{Key: a.Key, Value: slog.GroupValue()}
+			}
+			return lh.version(a.Key, *v)
+		}
+	case slog.KindString:
+		if slices.Contains(lh.opts.Keys, a.Key) {
+			if v, err := Parse(a.Value.String()); err == nil {
+				return lh.version(a.Key, v)
+			}
+		}
+	}
+	return a
+}
+
+func (lh *logHandler) version(key string, v SemVer) slog.Attr {
+	if lh.opts.Plain {
+		return slog.String(key, v.String())
+	}
+	return slog.Attr{Key: key, Value: v.LogValue()}
+}
@@ -0,0 +1,94 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestSemVer_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("start", "version", SemVer{Major: 3, Minor: 1, PreRelease: "rc.1", Build: "b.5"})
+	want := "msg=start version.major=3 version.minor=1 version.patch=0 version.prerelease=rc.1 version.build=b.5 version.full=3.1.0-rc.1+b.5\n"
+	if got := buf.String(); got != want {
+		t.Errorf("LogValue() = %q, want %q", got, want)
+	}
+}
+
+// logJSON logs with a JSON handler wrapped by NewLogHandler and returns the decoded record.
+func logJSON(t *testing.T, opts *LogHandlerOptions, log func(*slog.Logger)) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	log(slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil), opts)))
+	var m map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	delete(m, slog.TimeKey)
+	delete(m, slog.LevelKey)
+	delete(m, slog.MessageKey)
+	return m
+}
+
+func TestNewLogHandler(t *testing.T) {
+	group := map[string]any{"major": 2.0, "minor": 3.0, "patch": 0.0, "prerelease": "", "build": "", "full": "2.3.0"}
+	tests := []struct {
+		name string
+		opts *LogHandlerOptions
+		log  func(*slog.Logger)
+		want map[string]any
+	}{
+		{name: "nil options",
+			log:  func(l *slog.Logger) { l.Info("", "v", SemVer{Major: 2, Minor: 3}, "client", "2.3.0") },
+			want: map[string]any{"v": group, "client": "2.3.0"},
+		},
+		{name: "tagged key",
+			opts: &LogHandlerOptions{Keys: []string{"client"}},
+			log:  func(l *slog.Logger) { l.Info("", "client", "2.3.0", "other", "2.3.0") },
+			want: map[string]any{"client": group, "other": "2.3.0"},
+		},
+		{name: "tagged key with invalid version",
+			opts: &LogHandlerOptions{Keys: []string{"client"}},
+			log:  func(l *slog.Logger) { l.Info("", "client", "2.3") },
+			want: map[string]any{"client": "2.3"},
+		},
+		{name: "tagged key in group and WithAttrs",
+			opts: &LogHandlerOptions{Keys: []string{"client"}},
+			log: func(l *slog.Logger) {
+				l.With("client", "2.3.0").WithGroup("req").Info("", slog.Group("hdr", "client", "2.3.0"))
+			},
+			want: map[string]any{"client": group, "req": map[string]any{"hdr": map[string]any{"client": group}}},
+		},
+		{name: "plain",
+			opts: &LogHandlerOptions{Keys: []string{"client"}, Plain: true},
+			log:  func(l *slog.Logger) { l.Info("", "v", SemVer{Major: 2, Minor: 3, Build: "b"}, "client", "2.3.0") },
+			want: map[string]any{"v": "2.3.0+b", "client": "2.3.0"},
+		},
+		{name: "pointer",
+			log:  func(l *slog.Logger) { l.Info("", "v", &SemVer{Major: 2, Minor: 3}, "nil", (*SemVer)(nil)) },
+			want: map[string]any{"v": group},
+		},
+		{name: "pointer plain",
+			opts: &LogHandlerOptions{Plain: true},
+			log:  func(l *slog.Logger) { l.Info("", "v", &SemVer{Major: 2, Minor: 3, Build: "b"}, "nil", (*SemVer)(nil)) },
+			want: map[string]any{"v": "2.3.0+b", "nil": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logJSON(t, tt.opts, tt.log); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewLogHandler() logged %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
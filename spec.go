@@ -0,0 +1,234 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Spec identifies a version of the Semantic Versioning Specification.
+type Spec int
+
+const (
+	// Spec200 is [Semantic Versioning 2.0.0], the default.
+	//
+	// [Semantic Versioning 2.0.0]: https://semver.org/spec/v2.0.0.html
+	Spec200 Spec = iota
+	// Spec100 is [Semantic Versioning 1.0.0].
+	// It has no build metadata, its pre-release version is a single string of [0-9A-Za-z-]
+	// (no dot-separated identifiers) and pre-release versions are compared in lexicographic ASCII order,
+	// so e.g. "1.0.0-beta10" is less than "1.0.0-beta2".
+	//
+	// [Semantic Versioning 1.0.0]: https://semver.org/spec/v1.0.0.html
+	Spec100
+)
+
+// String implements the [fmt.Stringer] interface.
+func (spec Spec) String() string {
+	switch spec {
+	case Spec200:
+		return "2.0.0"
+	case Spec100:
+		return "1.0.0"
+	}
+	return fmt.Sprintf("Spec(%d)", int(spec))
+}
+
+// ParseSpec converts the version string to a [SemVer] according to 'spec'.
+// ParseSpec(s, Spec200) is equivalent to [Parse](s).
+func ParseSpec(s string, spec Spec) (SemVer, error) {
+	switch spec {
+	case Spec200:
+		return Parse(s)
+	case Spec100:
+		return parse100(s)
+	}
+	return SemVer{}, errors.New("unknown spec")
+}
+
+func parse100(s string) (SemVer, error) {
+	ss := strings.SplitN(s, ".", 3)
+	if len(ss) < 3 {
+		return SemVer{}, errors.New("malformed semver")
+	}
+	var sv SemVer
+	var err error
+	if sv.Major, err = strToVersionNumber(ss[0]); err != nil {
+		return SemVer{}, err
+	}
+	if sv.Minor, err = strToVersionNumber(ss[1]); err != nil {
+		return SemVer{}, err
+	}
+	patch, pre, hasPre := strings.Cut(ss[2], "-")
+	if sv.Patch, err = strToVersionNumber(patch); err != nil {
+		return SemVer{}, err
+	}
+	if hasPre {
+		if len(pre) == 0 {
+			return SemVer{}, errors.New("malformed semver")
+		}
+		sv.PreRelease = pre
+	}
+	if err := valid100(sv); err != nil {
+		return SemVer{}, err
+	}
+	return sv, nil
+}
+
+// ValidSpec checks 'sv' for validity according to 'spec'.
+// ValidSpec(sv, Spec200) is equivalent to [Valid](sv).
+func ValidSpec(sv SemVer, spec Spec) error {
+	switch spec {
+	case Spec200:
+		return Valid(sv)
+	case Spec100:
+		return valid100(sv)
+	}
+	return errors.New("unknown spec")
+}
+
+func valid100(sv SemVer) error {
+	// https://semver.org/spec/v1.0.0.html#spec-item-2
+	if sv.Major < 0 || sv.Minor < 0 || sv.Patch < 0 {
+		return errors.New("malformed semver")
+	}
+	// https://semver.org/spec/v1.0.0.html#spec-item-4
+	if len(sv.PreRelease) > 0 {
+		if err := validIdent(sv.PreRelease, false); err != nil {
+			return err
+		}
+	}
+	if len(sv.Build) > 0 {
+		return errors.New("build metadata is not supported by semver 1.0.0")
+	}
+	return nil
+}
+
+// CompareSpec compares 'sv1' with 'sv2' according to 'spec'.
+// CompareSpec returns -1 if 'sv1' is less than 'sv2', 0 if 'sv1' is equal to 'sv2', 1 if 'sv1' is more than 'sv2'.
+// CompareSpec(sv1, sv2, Spec200) is equivalent to [Compare](sv1, sv2).
+func CompareSpec(sv1, sv2 SemVer, spec Spec) (int, error) {
+	switch spec {
+	case Spec200:
+		return Compare(sv1, sv2)
+	case Spec100:
+		if err := valid100(sv1); err != nil {
+			return 0, err
+		}
+		if err := valid100(sv2); err != nil {
+			return 0, err
+		}
+		return compare100(sv1, sv2), nil
+	}
+	return 0, errors.New("unknown spec")
+}
+
+func compare100(sv1, sv2 SemVer) int {
+	if sv1.Major != sv2.Major {
+		return boolToCompareResult(sv1.Major > sv2.Major)
+	}
+	if sv1.Minor != sv2.Minor {
+		return boolToCompareResult(sv1.Minor > sv2.Minor)
+	}
+	if sv1.Patch != sv2.Patch {
+		return boolToCompareResult(sv1.Patch > sv2.Patch)
+	}
+	// https://semver.org/spec/v1.0.0.html#spec-item-4
+	pr1, pr2 := sv1.PreRelease, sv2.PreRelease
+	switch {
+	case pr1 == pr2:
+		return 0
+	case len(pr1) == 0:
+		return 1
+	case len(pr2) == 0:
+		return -1
+	}
+	return boolToCompareResult(pr1 > pr2)
+}
+
+// OrderError is returned by [ConvertSpec100] when the precedence of some
+// versions under 2.0.0 rules differs from their precedence under 1.0.0 rules.
+type OrderError struct {
+	// Pairs contains the affected pairs of converted versions; the first version of each pair
+	// is less than the second one under 1.0.0 rules.
+	Pairs [][2]SemVer
+}
+
+// Error implements the error interface.
+func (e *OrderError) Error() string {
+	var b strings.Builder
+	b.WriteString("precedence changes under semver 2.0.0:")
+	for i, p := range e.Pairs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, " %s < %s", p[0], p[1])
+	}
+	return b.String()
+}
+
+// ConvertSpec100 converts versions valid under [Spec100] to their [Spec200] form.
+// A pre-release version is split into dot-separated identifiers at each boundary
+// between letters and digits (e.g. "1.0.0-rc10" becomes "1.0.0-rc.10"),
+// unless the split would produce a numeric identifier with leading zeros (e.g. "1.0.0-rc01"),
+// in which case it is kept as is. The conversion of a version does not depend on the other versions.
+// A numeric pre-release version with leading zeros (e.g. "1.0.0-01"),
+// which is invalid under 2.0.0, cannot be converted and causes an error.
+//
+// Since 2.0.0 compares numeric identifiers numerically and ranks them below alphanumeric ones,
+// the precedence of converted versions may differ (e.g. "1.0.0-beta10" < "1.0.0-beta2" under 1.0.0,
+// but "1.0.0-beta.10" > "1.0.0-beta.2" under 2.0.0).
+// In this case ConvertSpec100 returns the converted versions together with an [*OrderError]
+// reporting all affected pairs.
+func ConvertSpec100(vv ...SemVer) ([]SemVer, error) {
+	r := make([]SemVer, len(vv))
+	for i, v := range vv {
+		if err := valid100(v); err != nil {
+			return nil, err
+		}
+		if err := Valid(v); err != nil {
+			return nil, fmt.Errorf("cannot convert %s to semver 2.0.0 (%w)", v, err)
+		}
+		if pre, ok := splitPreRelease100(v.PreRelease); ok {
+			v.PreRelease = pre
+		}
+		r[i] = v
+	}
+	var pairs [][2]SemVer
+	for i := 0; i < len(r); i++ {
+		for j := i + 1; j < len(r); j++ {
+			c1 := compare100(vv[i], vv[j])
+			c2, _ := Compare(r[i], r[j])
+			if c1 == c2 {
+				continue
+			}
+			if c1 < 0 {
+				pairs = append(pairs, [2]SemVer{r[i], r[j]})
+			} else {
+				pairs = append(pairs, [2]SemVer{r[j], r[i]})
+			}
+		}
+	}
+	if len(pairs) > 0 {
+		return r, &OrderError{Pairs: pairs}
+	}
+	return r, nil
+}
+
+// splitPreRelease100 inserts dots between letters and digits of 1.0.0 pre-release version 'pre'.
+// It reports false if nothing is split or the result is not a valid 2.0.0 pre-release version.
+func splitPreRelease100(pre string) (string, bool) {
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	isLetter := func(c byte) bool { return ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') }
+	var b strings.Builder
+	for i := 0; i < len(pre); i++ {
+		if i > 0 && ((isDigit(pre[i-1]) && isLetter(pre[i])) || (isLetter(pre[i-1]) && isDigit(pre[i]))) {
+			b.WriteByte('.')
+		}
+		b.WriteByte(pre[i])
+	}
+	if b.Len() == len(pre) || validExt(b.String(), true) != nil {
+		return "", false
+	}
+	return b.String(), true
+}
@@ -0,0 +1,154 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		spec    Spec
+		want    SemVer
+		wantErr bool
+	}{
+		{name: "01", s: "1.0.0-rc.1", spec: Spec100, wantErr: true},
+		{name: "02", s: "1.0.0+b", spec: Spec100, wantErr: true},
+		{name: "03", s: "1.0.0-", spec: Spec100, wantErr: true},
+		{name: "04", s: "1.0.0-rc_1", spec: Spec100, wantErr: true},
+		{name: "05", s: "01.0.0", spec: Spec100, wantErr: true},
+		{name: "06", s: "1.0.0", spec: Spec(7), wantErr: true},
+		{name: "07", s: "1.0.0-01", spec: Spec200, wantErr: true},
+		{name: "1", s: "1.0.0-rc1", spec: Spec100, want: SemVer{Major: 1, PreRelease: "rc1"}},
+		{name: "2", s: "1.0.0-01", spec: Spec100, want: SemVer{Major: 1, PreRelease: "01"}},
+		{name: "3", s: "1.2.3-x-y", spec: Spec100, want: SemVer{Major: 1, Minor: 2, Patch: 3, PreRelease: "x-y"}},
+		{name: "4", s: "1.0.0-rc.1+b", spec: Spec200, want: SemVer{Major: 1, PreRelease: "rc.1", Build: "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSpec(tt.s, tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSpec() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSpec() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertSpec100_Independent(t *testing.T) {
+	beta2, beta10 := SemVer{Major: 1, PreRelease: "beta2"}, SemVer{Major: 1, PreRelease: "beta10"}
+	alone, _ := ConvertSpec100(beta2)
+	for _, vv := range [][]SemVer{{beta2, beta10}, {beta10, beta2}} {
+		got, _ := ConvertSpec100(vv...)
+		for i, v := range vv {
+			if v == beta2 && got[i] != alone[0] {
+				t.Errorf("ConvertSpec100(%v)[%d] = %v, want %v", vv, i, got[i], alone[0])
+			}
+		}
+	}
+	if alone[0].PreRelease != "beta.2" {
+		t.Errorf("ConvertSpec100() = %v, want 1.0.0-beta.2", alone[0])
+	}
+}
+
+func TestCompareSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		s1, s2  string
+		spec    Spec
+		want    int
+		wantErr bool
+	}{
+		{name: "1", s1: "1.0.0-beta2", s2: "1.0.0-beta10", spec: Spec100, want: 1},
+		{name: "2", s1: "1.0.0-beta2", s2: "1.0.0-beta10", spec: Spec200, want: 1},
+		{name: "3", s1: "1.0.0-9", s2: "1.0.0-10", spec: Spec100, want: 1},
+		{name: "4", s1: "1.0.0-9", s2: "1.0.0-10", spec: Spec200, want: -1},
+		{name: "5", s1: "1.0.0-rc1", s2: "1.0.0", spec: Spec100, want: -1},
+		{name: "6", s1: "1.0.1", s2: "1.0.0-rc1", spec: Spec100, want: 1},
+		{name: "7", s1: "1.0.0-rc1", s2: "1.0.0-rc1", spec: Spec100, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompareSpec(parseMust(tt.s1), parseMust(tt.s2), tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CompareSpec() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CompareSpec() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := CompareSpec(SemVer{Build: "b"}, SemVer{}, Spec100); err == nil {
+		t.Errorf("CompareSpec() error = nil, want error")
+	}
+}
+
+func TestConvertSpec100(t *testing.T) {
+	tests := []struct {
+		name      string
+		vv        []SemVer
+		want      []SemVer
+		wantErr   bool
+		wantPairs [][2]SemVer
+	}{
+		{name: "01", vv: []SemVer{{Major: 1, PreRelease: "01"}}, wantErr: true},
+		{name: "02", vv: []SemVer{{Major: 1, PreRelease: "rc.1"}}, wantErr: true},
+		{name: "1", vv: []SemVer{}, want: []SemVer{}},
+		{name: "2",
+			vv:        []SemVer{{Major: 1, PreRelease: "beta10"}, {Major: 1, PreRelease: "beta2"}, {Major: 1}},
+			want:      []SemVer{{Major: 1, PreRelease: "beta.10"}, {Major: 1, PreRelease: "beta.2"}, {Major: 1}},
+			wantErr:   true,
+			wantPairs: [][2]SemVer{{{Major: 1, PreRelease: "beta.10"}, {Major: 1, PreRelease: "beta.2"}}},
+		},
+		{name: "3",
+			vv:        []SemVer{{Major: 1, PreRelease: "10"}, {Major: 1, PreRelease: "9"}, {Major: 1, PreRelease: "a"}},
+			want:      []SemVer{{Major: 1, PreRelease: "10"}, {Major: 1, PreRelease: "9"}, {Major: 1, PreRelease: "a"}},
+			wantErr:   true,
+			wantPairs: [][2]SemVer{{{Major: 1, PreRelease: "10"}, {Major: 1, PreRelease: "9"}}},
+		},
+		{name: "4",
+			vv:        []SemVer{{Major: 1, PreRelease: "9"}, {Major: 1, PreRelease: "10a"}},
+			want:      []SemVer{{Major: 1, PreRelease: "9"}, {Major: 1, PreRelease: "10.a"}},
+			wantErr:   true,
+			wantPairs: [][2]SemVer{{{Major: 1, PreRelease: "10.a"}, {Major: 1, PreRelease: "9"}}},
+		},
+		{name: "5",
+			vv:   []SemVer{{Major: 1, PreRelease: "rc10"}, {Major: 1, PreRelease: "beta2"}, {Major: 1}},
+			want: []SemVer{{Major: 1, PreRelease: "rc.10"}, {Major: 1, PreRelease: "beta.2"}, {Major: 1}},
+		},
+		{name: "6",
+			vv:        []SemVer{{Major: 1, PreRelease: "rc10"}, {Major: 1, PreRelease: "rc-1"}},
+			want:      []SemVer{{Major: 1, PreRelease: "rc.10"}, {Major: 1, PreRelease: "rc-1"}},
+			wantErr:   true,
+			wantPairs: [][2]SemVer{{{Major: 1, PreRelease: "rc-1"}, {Major: 1, PreRelease: "rc.10"}}},
+		},
+		{name: "7",
+			vv:        []SemVer{{Major: 1, PreRelease: "rc01"}, {Major: 1, PreRelease: "rc1b"}, {Major: 2, PreRelease: "1rc"}},
+			want:      []SemVer{{Major: 1, PreRelease: "rc01"}, {Major: 1, PreRelease: "rc.1.b"}, {Major: 2, PreRelease: "1.rc"}},
+			wantErr:   true,
+			wantPairs: [][2]SemVer{{{Major: 1, PreRelease: "rc01"}, {Major: 1, PreRelease: "rc.1.b"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertSpec100(tt.vv...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConvertSpec100() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertSpec100() = %v, want %v", got, tt.want)
+			}
+			var oe *OrderError
+			if errors.As(err, &oe) && !reflect.DeepEqual(oe.Pairs, tt.wantPairs) {
+				t.Errorf("OrderError.Pairs = %v, want %v", oe.Pairs, tt.wantPairs)
+			}
+		})
+	}
+}
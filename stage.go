@@ -0,0 +1,214 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Stage represents the release stage of a [SemVer] derived from the label
+// that starts its [pre-release version] (e.g. "rc" in "1.0.0-rc.2").
+// Labels are recognised case-insensitively.
+// Known stages are ordered: [StageDev] < [StageAlpha] < [StageBeta] < [StageRC] < [StageRelease].
+//
+// Stage ordering is independent of [precedence], so [Compare] does not use it.
+//
+// [pre-release version]: https://semver.org/#spec-item-9
+// [precedence]: https://semver.org/#spec-item-11
+type Stage int
+
+const (
+	// StageUnknown is the stage of a pre-release version with unrecognised label.
+	StageUnknown Stage = iota
+	// StageDev is the stage of "dev" pre-release versions.
+	StageDev
+	// StageAlpha is the stage of "alpha" and "a" pre-release versions.
+	StageAlpha
+	// StageBeta is the stage of "beta" and "b" pre-release versions.
+	StageBeta
+	// StageRC is the stage of "rc" and "pre" pre-release versions.
+	StageRC
+	// StageRelease is the stage of a version without pre-release version.
+	StageRelease
+)
+
+var stageLabels = map[string]Stage{
+	"dev":   StageDev,
+	"alpha": StageAlpha,
+	"a":     StageAlpha,
+	"beta":  StageBeta,
+	"b":     StageBeta,
+	"rc":    StageRC,
+	"pre":   StageRC,
+}
+
+// String implements the [fmt.Stringer] interface.
+// String returns the canonical label of the stage.
+func (s Stage) String() string {
+	switch s {
+	case StageDev:
+		return "dev"
+	case StageAlpha:
+		return "alpha"
+	case StageBeta:
+		return "beta"
+	case StageRC:
+		return "rc"
+	case StageRelease:
+		return "release"
+	}
+	return "unknown"
+}
+
+// stageKey is the stage ordering key of a pre-release version.
+type stageKey struct {
+	stage  Stage
+	num    int64
+	hasNum bool
+}
+
+// parseStage extracts the stage and the stage number from 'pr'.
+// The number either immediately follows the label ("rc2")
+// or is the next numeric identifier ("rc.2").
+func parseStage(pr string) stageKey {
+	if len(pr) == 0 {
+		return stageKey{stage: StageRelease}
+	}
+	ids := strings.Split(pr, ".")
+	first := strings.ToLower(ids[0])
+	i := len(first)
+	for i > 0 && '0' <= first[i-1] && first[i-1] <= '9' {
+		i--
+	}
+	label, digits := first[:i], first[i:]
+	stage, ok := stageLabels[label]
+	if !ok {
+		return stageKey{stage: StageUnknown}
+	}
+	if len(digits) == 0 && len(ids) > 1 && isNumeric(ids[1]) {
+		digits = ids[1]
+	}
+	if len(digits) == 0 {
+		return stageKey{stage: stage}
+	}
+	num, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return stageKey{stage: stage}
+	}
+	return stageKey{stage: stage, num: num, hasNum: true}
+}
+
+// compareStageKeys compares stage keys the way [Compare] compares versions:
+// a key without number is less than the key of the same stage with number.
+func compareStageKeys(k1, k2 stageKey) int {
+	if k1.stage != k2.stage {
+		return boolToCompareResult(k1.stage > k2.stage)
+	}
+	if k1.hasNum != k2.hasNum {
+		return boolToCompareResult(k1.hasNum)
+	}
+	if k1.num != k2.num {
+		return boolToCompareResult(k1.num > k2.num)
+	}
+	return 0
+}
+
+// Stage returns the release stage of 'v'.
+func (v SemVer) Stage() Stage {
+	return parseStage(v.PreRelease).stage
+}
+
+// StageNumber returns the number that follows the stage label of 'v'
+// (e.g. 2 for both "1.0.0-rc.2" and "1.0.0-rc2").
+// The boolean result reports whether 'v' has a recognised stage followed by a number.
+func (v SemVer) StageNumber() (int64, bool) {
+	k := parseStage(v.PreRelease)
+	return k.num, k.hasNum
+}
+
+// PromoteTo returns 'v' promoted to 'stage'.
+// The result has the major, minor and patch versions of 'v' and no build metadata.
+// Its pre-release version is the canonical label of 'stage' followed by ".1" (e.g. "beta.1"),
+// or is empty if 'stage' is [StageRelease].
+//
+// PromoteTo returns an error if 'v' is invalid, if 'stage' is not higher than the stage of 'v',
+// if 'v' has unknown stage and 'stage' is not [StageRelease],
+// or if the result would not have higher precedence than 'v'
+// (the canonical labels are not in lexical order, e.g. "dev" > "alpha").
+func (v SemVer) PromoteTo(stage Stage) (SemVer, error) {
+	if err := Valid(v); err != nil {
+		return SemVer{}, err
+	}
+	if stage <= StageUnknown || stage > StageRelease {
+		return SemVer{}, errors.New("unknown stage")
+	}
+	current := v.Stage()
+	if current == StageUnknown && stage != StageRelease {
+		return SemVer{}, errors.New("unknown stage")
+	}
+	if stage <= current {
+		return SemVer{}, errors.New("stage is not higher")
+	}
+	r := SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if stage != StageRelease {
+		r.PreRelease = stage.String() + ".1"
+	}
+	if compareValid(r, v) <= 0 {
+		return SemVer{}, fmt.Errorf("%s does not have higher precedence than %s", r, v)
+	}
+	return r, nil
+}
+
+// StageConflict describes two versions whose [precedence] disagrees with their stage ordering.
+//
+// [precedence]: https://semver.org/#spec-item-11
+type StageConflict struct {
+	// Lower is the version with the lower stage ordering.
+	Lower SemVer
+	// Higher is the version with the higher stage ordering,
+	// which nevertheless does not have higher precedence than Lower.
+	Higher SemVer
+}
+
+// LintStages returns all pairs of 'vv' whose precedence disagrees with their stage ordering
+// (e.g. "1.0.0-RC.1" has lower precedence than "1.0.0-alpha.1", since uppercase letters sort first).
+// Only versions with equal major, minor and patch versions and known stages are paired.
+// Invalid versions are ignored.
+func LintStages(vv ...SemVer) []StageConflict {
+	keys := make([]stageKey, len(vv))
+	for i, v := range vv {
+		if Valid(v) != nil {
+			keys[i] = stageKey{stage: StageUnknown}
+			continue
+		}
+		keys[i] = parseStage(v.PreRelease)
+	}
+	var cc []StageConflict
+	for i := 0; i < len(vv); i++ {
+		if keys[i].stage == StageUnknown {
+			continue
+		}
+		for j := i + 1; j < len(vv); j++ {
+			if keys[j].stage == StageUnknown {
+				continue
+			}
+			if vv[i].Major != vv[j].Major || vv[i].Minor != vv[j].Minor || vv[i].Patch != vv[j].Patch {
+				continue
+			}
+			byStage := compareStageKeys(keys[i], keys[j])
+			if byStage == 0 {
+				continue
+			}
+			if comparePreRelease(vv[i].PreRelease, vv[j].PreRelease) == byStage {
+				continue
+			}
+			if byStage < 0 {
+				cc = append(cc, StageConflict{Lower: vv[i], Higher: vv[j]})
+			} else {
+				cc = append(cc, StageConflict{Lower: vv[j], Higher: vv[i]})
+			}
+		}
+	}
+	return cc
+}
@@ -0,0 +1,158 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSemVer_Stage(t *testing.T) {
+	tests := []struct {
+		name string
+		v    SemVer
+		want Stage
+	}{
+		{name: "1", v: parseMust("1.0.0"), want: StageRelease},
+		{name: "2", v: parseMust("1.0.0-dev"), want: StageDev},
+		{name: "3", v: parseMust("1.0.0-alpha.1"), want: StageAlpha},
+		{name: "4", v: parseMust("1.0.0-a1"), want: StageAlpha},
+		{name: "5", v: parseMust("1.0.0-Beta.2"), want: StageBeta},
+		{name: "6", v: parseMust("1.0.0-b"), want: StageBeta},
+		{name: "7", v: parseMust("1.0.0-RC.1"), want: StageRC},
+		{name: "8", v: parseMust("1.0.0-pre3"), want: StageRC},
+		{name: "9", v: parseMust("1.0.0-snapshot"), want: StageUnknown},
+		{name: "10", v: parseMust("1.0.0-1.rc"), want: StageUnknown},
+		{name: "11", v: parseMust("1.0.0-rc-1"), want: StageUnknown},
+		{name: "12", v: parseMust("1.0.0+rc.1"), want: StageRelease},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Stage(); got != tt.want {
+				t.Errorf("SemVer.Stage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVer_StageNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      SemVer
+		want   int64
+		wantOk bool
+	}{
+		{name: "1", v: parseMust("1.0.0")},
+		{name: "2", v: parseMust("1.0.0-rc")},
+		{name: "3", v: parseMust("1.0.0-rc.2"), want: 2, wantOk: true},
+		{name: "4", v: parseMust("1.0.0-RC2"), want: 2, wantOk: true},
+		{name: "5", v: parseMust("1.0.0-beta.0"), want: 0, wantOk: true},
+		{name: "6", v: parseMust("1.0.0-beta.x.3")},
+		{name: "7", v: parseMust("1.0.0-snapshot.3")},
+		{name: "8", v: parseMust("1.0.0-rc.99999999999999999999")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.v.StageNumber()
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("SemVer.StageNumber() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestSemVer_PromoteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       SemVer
+		stage   Stage
+		want    SemVer
+		wantErr bool
+	}{
+		{name: "01", v: SemVer{Major: -1}, stage: StageRelease, wantErr: true},
+		{name: "02", v: parseMust("1.0.0-beta.3"), stage: StageAlpha, wantErr: true},
+		{name: "03", v: parseMust("1.0.0-beta.3"), stage: StageBeta, wantErr: true},
+		{name: "04", v: parseMust("1.0.0"), stage: StageRC, wantErr: true},
+		{name: "05", v: parseMust("1.0.0-snapshot"), stage: StageRC, wantErr: true},
+		{name: "06", v: parseMust("1.0.0-rc.1"), stage: StageUnknown, wantErr: true},
+		{name: "07", v: parseMust("1.0.0-rc.1"), stage: Stage(42), wantErr: true},
+		{name: "08", v: parseMust("1.0.0-dev.5"), stage: StageAlpha, wantErr: true},
+		{name: "09", v: parseMust("1.0.0-dev"), stage: StageBeta, wantErr: true},
+		{name: "1",
+			v:     parseMust("1.2.3-alpha.4+b.5"),
+			stage: StageBeta,
+			want:  SemVer{Major: 1, Minor: 2, Patch: 3, PreRelease: "beta.1"},
+		},
+		{name: "2",
+			v:     parseMust("1.2.3-dev"),
+			stage: StageRC,
+			want:  SemVer{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1"},
+		},
+		{name: "3",
+			v:     parseMust("1.2.3-RC.2"),
+			stage: StageRelease,
+			want:  SemVer{Major: 1, Minor: 2, Patch: 3},
+		},
+		{name: "4",
+			v:     parseMust("1.2.3-snapshot"),
+			stage: StageRelease,
+			want:  SemVer{Major: 1, Minor: 2, Patch: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.v.PromoteTo(tt.stage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SemVer.PromoteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SemVer.PromoteTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintStages(t *testing.T) {
+	tests := []struct {
+		name string
+		vv   []SemVer
+		want []StageConflict
+	}{
+		{name: "1"},
+		{name: "2",
+			vv: []SemVer{parseMust("1.0.0-alpha.1"), parseMust("1.0.0-beta.1"), parseMust("1.0.0-rc.1"), parseMust("1.0.0")},
+		},
+		{name: "3",
+			vv: []SemVer{parseMust("1.0.0-RC.1"), parseMust("1.0.0-alpha.1")},
+			want: []StageConflict{
+				{Lower: parseMust("1.0.0-alpha.1"), Higher: parseMust("1.0.0-RC.1")},
+			},
+		},
+		{name: "4",
+			vv: []SemVer{parseMust("1.0.0-dev.1"), parseMust("1.0.0-alpha.1")},
+			want: []StageConflict{
+				{Lower: parseMust("1.0.0-dev.1"), Higher: parseMust("1.0.0-alpha.1")},
+			},
+		},
+		{name: "5",
+			vv: []SemVer{parseMust("1.0.0-rc2"), parseMust("1.0.0-rc10")},
+			want: []StageConflict{
+				{Lower: parseMust("1.0.0-rc2"), Higher: parseMust("1.0.0-rc10")},
+			},
+		},
+		// Different cores, unknown stages and invalid versions are not paired.
+		{name: "6",
+			vv: []SemVer{parseMust("1.0.0-RC.1"), parseMust("1.0.1-alpha.1"), parseMust("1.0.0-zeta"), {Major: -1, PreRelease: "beta"}},
+		},
+		// Equal stage keys are not conflicts.
+		{name: "7",
+			vv: []SemVer{parseMust("1.0.0-rc.1"), parseMust("1.0.0-RC.1")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LintStages(tt.vv...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintStages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package semver
+
+import (
+	"encoding/binary"
+	"slices"
+	"strings"
+)
+
+// CompareStrict compares 'sv1' with 'sv2' in the total order of versions:
+// by [precedence], and versions of equal precedence by build metadata,
+// a version without build metadata first, others in lexical (byte-wise) order of build metadata.
+// CompareStrict returns 0 only for [Identical] versions.
+//
+// [precedence]: https://semver.org/#spec-item-11
+func CompareStrict(sv1, sv2 SemVer) (int, error) {
+	if err := Valid(sv1); err != nil {
+		return 0, err
+	}
+	if err := Valid(sv2); err != nil {
+		return 0, err
+	}
+	return compareStrictValid(sv1, sv2), nil
+}
+
+// compareStrictValid compares already validated 'sv1' and 'sv2' like [CompareStrict].
+func compareStrictValid(sv1, sv2 SemVer) int {
+	if r := compareValid(sv1, sv2); r != 0 {
+		return r
+	}
+	return strings.Compare(sv1.Build, sv2.Build)
+}
+
+// Identical reports whether 'sv1' and 'sv2' are the same version including build metadata.
+// Unlike [SemVer.EqualTo], which reports equal precedence,
+// Identical tells "1.0.0+a" and "1.0.0+b" apart.
+func Identical(sv1, sv2 SemVer) bool {
+	return sv1 == sv2
+}
+
+// Key returns a string that is the same for [Identical] versions and different otherwise,
+// usable as a map key or a database key. Keys of valid versions compare (byte-wise)
+// in the order of [CompareStrict], so sorted keys are sorted versions.
+// The key is a binary string, not a version string; the key of an invalid version is unspecified.
+func (v SemVer) Key() string {
+	b := make([]byte, 0, 3*8+len(v.PreRelease)+len(v.Build)+8)
+	// version numbers are fixed-length big-endian
+	b = binary.BigEndian.AppendUint64(b, uint64(v.Major))
+	b = binary.BigEndian.AppendUint64(b, uint64(v.Minor))
+	b = binary.BigEndian.AppendUint64(b, uint64(v.Patch))
+	if len(v.PreRelease) == 0 {
+		// a release version follows its pre-release versions
+		b = append(b, 2)
+	} else {
+		b = append(b, 1)
+		for _, id := range strings.Split(v.PreRelease, ".") {
+			if isNumeric(id) {
+				// numeric identifiers precede alphanumeric ones; the longer number is the larger one
+				b = append(b, 1)
+				b = appendKeyLen(b, len(id))
+				b = append(b, id...)
+			} else {
+				// 0 terminates the identifier, so a prefix precedes the longer identifier
+				b = append(b, 2)
+				b = append(b, id...)
+				b = append(b, 0)
+			}
+		}
+		// 0 ends the identifiers, so fewer identifiers precede more
+		b = append(b, 0)
+	}
+	// the precedence part is prefix-free, so build metadata is appended as is
+	return string(append(b, v.Build...))
+}
+
+// appendKeyLen appends the order-preserving encoding of 'n' to 'b'.
+func appendKeyLen(b []byte, n int) []byte {
+	if n < 0xff {
+		return append(b, byte(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, 0xff), uint32(n))
+}
+
+// SortStable sorts 'vv' in ascending order of [CompareStrict].
+// The result does not depend on the initial order of 'vv'.
+// If any of 'vv' is invalid, SortStable returns an error and leaves 'vv' unchanged.
+func SortStable(vv []SemVer) error {
+	return SortStableFunc(vv, func(v SemVer) SemVer { return v })
+}
+
+// SortStableFunc sorts 's' in ascending order of [CompareStrict] of the versions of its elements
+// returned by 'version', keeping the original order of elements with [Identical] versions.
+// If any of the versions is invalid, SortStableFunc returns an error and leaves 's' unchanged.
+func SortStableFunc[E any](s []E, version func(E) SemVer) error {
+	for _, e := range s {
+		if err := Valid(version(e)); err != nil {
+			return err
+		}
+	}
+	slices.SortStableFunc(s, func(a, b E) int {
+		return compareStrictValid(version(a), version(b))
+	})
+	return nil
+}
@@ -0,0 +1,150 @@
+package semver
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompareStrict(t *testing.T) {
+	tests := []struct {
+		sv1, sv2 string
+		want     int
+	}{
+		{sv1: "1.0.0", sv2: "1.0.0", want: 0},
+		{sv1: "1.0.0+a", sv2: "1.0.0+a", want: 0},
+		{sv1: "1.0.0", sv2: "1.0.0+a", want: -1},
+		{sv1: "1.0.0+b", sv2: "1.0.0+a", want: 1},
+		{sv1: "1.0.0+a.10", sv2: "1.0.0+a.9", want: -1},
+		{sv1: "1.0.0+z", sv2: "1.0.1", want: -1},
+		{sv1: "1.0.0-rc.1+z", sv2: "1.0.0-rc.2+a", want: -1},
+		{sv1: "1.0.0-rc.1+b", sv2: "1.0.0-rc.1+a", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sv1+" "+tt.sv2, func(t *testing.T) {
+			sv1, sv2 := mustParse(t, tt.sv1), mustParse(t, tt.sv2)
+			got, err := CompareStrict(sv1, sv2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("CompareStrict() = %d, want %d", got, tt.want)
+			}
+			if Identical(sv1, sv2) != (tt.want == 0) {
+				t.Errorf("Identical() = %v", Identical(sv1, sv2))
+			}
+			if precedence, _ := Compare(sv1, sv2); precedence != 0 && precedence != got {
+				t.Errorf("CompareStrict() = %d disagrees with Compare() = %d", got, precedence)
+			}
+		})
+	}
+	if _, err := CompareStrict(SemVer{}, SemVer{Major: -1}); err == nil {
+		t.Errorf("CompareStrict() error = nil")
+	}
+}
+
+// strictVersions returns versions exercising all parts of the total order.
+func strictVersions(n int) []SemVer {
+	r := rand.New(rand.NewSource(1))
+	ids := []string{"0", "1", "2", "9", "10", "11", "123456789012345678901234567890", "a", "ab", "abc", "b", "-", "A", "rc", "rc1"}
+	ext := func() string {
+		ss := make([]string, 1+r.Intn(3))
+		for i := range ss {
+			ss[i] = ids[r.Intn(len(ids))]
+		}
+		return strings.Join(ss, ".")
+	}
+	vv := make([]SemVer, n)
+	for i := range vv {
+		v := SemVer{Major: r.Int63n(3), Minor: r.Int63n(3), Patch: r.Int63n(3)}
+		if r.Intn(10) == 0 {
+			v.Major = 1<<63 - 1 - r.Int63n(2)
+		}
+		if r.Intn(2) == 0 {
+			v.PreRelease = ext()
+		}
+		if r.Intn(3) == 0 {
+			v.Build = strings.ReplaceAll(ext(), "123456789012345678901234567890", "007")
+		}
+		vv[i] = v
+	}
+	return vv
+}
+
+func TestSemVer_Key(t *testing.T) {
+	vv := strictVersions(5000)
+	long := SemVer{PreRelease: strings.Repeat("9", 300)}
+	longer := SemVer{PreRelease: strings.Repeat("1", 301)}
+	vv = append(vv, long, longer, SemVer{PreRelease: strings.Repeat("a", 300)})
+	for _, v := range vv {
+		if err := Valid(v); err != nil {
+			t.Fatalf("%v: %v", v, err)
+		}
+	}
+	byCompare := slices.Clone(vv)
+	slices.SortFunc(byCompare, compareStrictValid)
+	byKey := slices.Clone(vv)
+	slices.SortFunc(byKey, func(a, b SemVer) int { return strings.Compare(a.Key(), b.Key()) })
+	for i := range byCompare {
+		if !Identical(byCompare[i], byKey[i]) {
+			t.Fatalf("order by Key() differs at %d: %v, want %v", i, byKey[i], byCompare[i])
+		}
+	}
+	keys := make(map[string]SemVer)
+	for _, v := range vv {
+		if other, ok := keys[v.Key()]; ok && !Identical(v, other) {
+			t.Fatalf("Key() of %v and %v is the same", v, other)
+		}
+		keys[v.Key()] = v
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	vv := strictVersions(1000)
+	shuffled := slices.Clone(vv)
+	rand.New(rand.NewSource(2)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	if err := SortStable(vv); err != nil {
+		t.Fatal(err)
+	}
+	if err := SortStable(shuffled); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(vv, shuffled) {
+		t.Errorf("SortStable() depends on the initial order")
+	}
+	if !slices.IsSortedFunc(vv, compareStrictValid) {
+		t.Errorf("SortStable() is not sorted")
+	}
+
+	unsorted := []SemVer{{Major: 2}, {Major: -1}, {Major: 1}}
+	if err := SortStable(unsorted); err == nil || unsorted[0].Major != 2 {
+		t.Errorf("SortStable() of invalid versions = %v, %v", unsorted, err)
+	}
+}
+
+func TestSortStableFunc(t *testing.T) {
+	type artifact struct {
+		name string
+		v    SemVer
+	}
+	aa := []artifact{
+		{"linux", SemVer{Major: 1, Build: "b"}},
+		{"windows", SemVer{Major: 1, Build: "a"}},
+		{"darwin", SemVer{Major: 1, Build: "b"}},
+		{"old", SemVer{Major: 0, Minor: 9}},
+		{"plain", SemVer{Major: 1}},
+	}
+	if err := SortStableFunc(aa, func(a artifact) SemVer { return a.v }); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, a := range aa {
+		names = append(names, a.name)
+	}
+	if got, want := strings.Join(names, " "), "old plain windows linux darwin"; got != want {
+		t.Errorf("SortStableFunc() = %v, want %v", got, want)
+	}
+}
@@ -42,7 +42,8 @@ func validExt(ext string, preRelease bool) error {
 	if len(ext) == 0 {
 		return nil
 	}
-	for _, ident := range strings.Split(ext, ".") {
+	// SplitSeq iterates without allocating a slice of identifiers
+	for ident := range strings.SplitSeq(ext, ".") {
 		if err := validIdent(ident, preRelease); err != nil {
 			return err
 		}